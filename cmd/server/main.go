@@ -1,15 +1,28 @@
 package main
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"flag"
+	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime/debug"
+	"sort"
+	"syscall"
 
 	"go-ver-trace/internal/analyzer"
+	"go-ver-trace/internal/classifier"
 	"go-ver-trace/internal/database"
 	"go-ver-trace/internal/importer"
+	"go-ver-trace/internal/platform"
 	"go-ver-trace/internal/scraper"
 	"go-ver-trace/internal/server"
+	"go-ver-trace/internal/summarizer"
 )
 
 func main() {
@@ -17,12 +30,56 @@ func main() {
 		port      = flag.Int("port", 8080, "サーバーポート")
 		dbPath    = flag.String("db", "data.db", "データベースファイルパス")
 		refresh   = flag.Bool("refresh", false, "起動時にデータを再取得する")
+		backfill  = flag.Bool("backfill", false, "Go 1.0〜1.17 の過去リリースも取得する（-refresh / -data-only と併用）")
+		parserOverrides = flag.String("parser-overrides", "", "バージョンごとの解析方法を指定する JSON ファイル（組み込みの指定を置き換える）")
 		dataOnly  = flag.Bool("data-only", false, "データ取得のみ実行してサーバーは起動しない")
 		importJSON = flag.String("import-json", "", "マイナーリビジョンJSONファイルをインポートする")
-		createBase = flag.Bool("create-base", false, "マイナーバージョンパッケージ用のベースエントリを作成する")
+		validateJSON = flag.String("validate-json", "", "マイナーリビジョンJSONファイル（ディレクトリの場合は中の *.json）をスキーマと保存済みのリリースで検証する")
+		offline      = flag.Bool("offline", false, "-validate-json: リンク先の URL を確認しない")
+		createBase = flag.Bool("create-base", false, "ベースエントリをリリースと変更から導出し直す（取り込みのたびに自動で導出する）")
+		importOSV  = flag.String("import-osv", "", "Go脆弱性データベース（vulndb）のOSVディレクトリをインポートする")
+		importMarkdown = flag.String("import-markdown", "", "リリースノートの Markdown（x/website または Go リポジトリのチェックアウト）をインポートする")
+		godebugGo  = flag.String("godebug-go", "", "GODEBUG差分: モジュールの go.mod の go バージョン（-godebug-toolchain と併用）")
+		godebugTC  = flag.String("godebug-toolchain", "", "GODEBUG差分: 対象のツールチェーンバージョン")
+		diffFrom   = flag.String("diff-from", "", "リリース差分: 現在のバージョン（-diff-to と併用）")
+		diffTo     = flag.String("diff-to", "", "リリース差分: 引き上げ先のバージョン")
+		platform   = flag.String("platform", "", "リリース差分: 対象プラットフォーム（例: linux/amd64,linux/arm64）")
+		upcoming   = flag.Bool("upcoming", false, "リリース差分: 正式リリース前のプレリリースの変更も含める")
+		prerelease = flag.Bool("prerelease", false, "正式リリース前のプレリリース（rc）と tip の草稿のリリースノートも取得する（-refresh / -data-only と併用）")
+		scrapeWorkers = flag.Int("scrape-workers", scraper.DefaultWorkers, "同時に取得するリリースノートの数")
+		scrapeRate    = flag.Float64("scrape-rate", scraper.DefaultRequestsPerSec, "go.dev への 1 秒あたりのリクエスト数の上限")
+		httpCache     = flag.String("http-cache", scraper.DefaultHTTPCacheDir, "取得したページを保存し、条件付きリクエストに使うディレクトリ（空の場合は使わない）")
+		reparse       = flag.Bool("reparse", false, "リリースノートが更新されていなくても解析し直す")
+		rollbackRun = flag.Int("rollback-run", 0, "指定した実行記録で保存したリリース・変更を削除する")
+		dryRun      = flag.Bool("dry-run", false, "データベースをメモリ上に複製して取得・インポートを実行し、変更内容を表示する（データベースには書き込まない）")
+		dryRunFormat = flag.String("dry-run-format", "text", "ドライランの出力形式（text / json）")
+		classifierRules = flag.String("classifier-rules", "", "変更種別の判定規則の JSON ファイル（組み込みの規則を置き換える）")
+		reclassify      = flag.Bool("reclassify", false, "保存済みの変更の種別を現在の判定規則で判定し直す")
+		resummarize     = flag.Bool("resummarize", false, "保存済みの変更の日本語要約を -summarizer で作り直す")
+		summarizerName  = flag.String("summarizer", "rule", "日本語要約の作成方法（rule: 規則による定型文 / llm: OpenAI 互換エンドポイント）")
+		llmEndpoint     = flag.String("llm-endpoint", summarizer.DefaultLLMEndpoint, "-summarizer llm: OpenAI 互換 API のベース URL")
+		llmModel        = flag.String("llm-model", "", "-summarizer llm: モデル名")
+		llmAPIKey       = flag.String("llm-api-key", os.Getenv("LLM_API_KEY"), "-summarizer llm: API キー（ローカルのモデルサーバーでは不要）")
+		importOverrides = flag.String("import-overrides", "", "手作業による補正（上書き設定）の YAML ファイルをインポートする")
+		overrideActor   = flag.String("override-actor", os.Getenv("USER"), "上書き設定・用語集・要約の更新者、実行記録を取り消した操作者として記録する名前（-import-overrides / -import-glossary / -import-translations / -rollback-run と併用）")
+		importGlossary  = flag.String("import-glossary", "", "用語集の CSV ファイルをインポートする")
+		exportGlossary  = flag.String("export-glossary", "", "用語集を CSV ファイルに書き出す（-translation-lang で言語を絞り込み可能）")
+		importTranslations = flag.String("import-translations", "", "言語ごとの要約の CSV ファイルをインポートする")
+		exportTranslations = flag.String("export-translations", "", "-translation-lang の要約を翻訳用の CSV ファイルに書き出す")
+		translationLang    = flag.String("translation-lang", "", "用語集・要約の書き出しの言語（BCP-47 言語タグ）")
+		adminToken      = flag.String("admin-token", os.Getenv("GO_VER_TRACE_ADMIN_TOKEN"), "管理 API（/api/admin/）の認証トークン（空の場合は管理 API を無効にする）")
 	)
 	flag.Parse()
 
+	// Ctrl-C・SIGTERM で取得中のスクレイピングとサーバーを停止する
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// ドライランの JSON 出力にログが混ざらないようにする
+	if *dryRun && *dryRunFormat == "json" {
+		log.SetOutput(os.Stderr)
+	}
+
 	// データベース初期化
 	db, err := database.New(*dbPath)
 	if err != nil {
@@ -32,14 +89,146 @@ func main() {
 
 	log.Printf("データベース初期化完了: %s", *dbPath)
 
+	// ドライランでは以降の処理をメモリ上の複製に対して行い、終了時に差分を表示する
+	if *dryRun {
+		if *dryRunFormat != "text" && *dryRunFormat != "json" {
+			log.Fatalf("-dry-run-format は text または json を指定してください: %q", *dryRunFormat)
+		}
+		preview, err := database.NewDryRun(*dbPath)
+		if err != nil {
+			log.Fatalf("ドライラン用データベースの作成に失敗しました: %v", err)
+		}
+		defer preview.Close()
+		defer printDryRunDiff(db, preview, *dryRunFormat)
+
+		db = preview
+		log.Println("ドライラン: データベースには書き込みません")
+	}
+
+	// 変更種別の判定規則
+	changeClassifier := classifier.Default()
+	if *classifierRules != "" {
+		changeClassifier, err = classifier.Load(*classifierRules)
+		if err != nil {
+			log.Fatalf("変更種別の判定規則の読み込みに失敗しました: %v", err)
+		}
+	}
+
+	// GODEBUG のデフォルト切り替わり一覧を表示して終了
+	if *godebugGo != "" || *godebugTC != "" {
+		if err := printGodebugFlips(db, *godebugGo, *godebugTC); err != nil {
+			log.Fatalf("GODEBUG差分の取得に失敗しました: %v", err)
+		}
+		return
+	}
+
+	// 実行記録の取り消し
+	if *rollbackRun != 0 {
+		if *overrideActor == "" {
+			log.Fatalf("-rollback-run には -override-actor を指定してください")
+		}
+		result, err := db.RollbackIngestionRun(*rollbackRun, *overrideActor)
+		if err != nil {
+			log.Fatalf("実行記録 #%d の取り消しに失敗しました: %v", *rollbackRun, err)
+		}
+		log.Printf("実行記録 #%d を取り消しました (削除したリリース: %d, 変更: %d, GODEBUG: %d / 元に戻したリリース: %d, 変更: %d, GODEBUG: %d)",
+			result.RunID, result.DeletedReleases, result.DeletedChanges, result.DeletedGodebugEvents, result.RestoredReleases, result.RestoredChanges, result.RestoredGodebugEvents)
+		return
+	}
+
+	// 保存済みの変更の再分類
+	if *reclassify {
+		if err := reclassifyChanges(db, changeClassifier); err != nil {
+			log.Fatalf("変更種別の再分類に失敗しました: %v", err)
+		}
+		return
+	}
+
+	// 保存済みの変更の日本語要約の作り直し
+	if *resummarize {
+		s, err := summarizer.New(*summarizerName, summarizer.LLMConfig{Endpoint: *llmEndpoint, Model: *llmModel, APIKey: *llmAPIKey})
+		if err != nil {
+			log.Fatalf("要約の作成方法の設定に失敗しました: %v", err)
+		}
+		// 用語集の訳語を使い、LLM による要約は説明文ごとにデータベースに保存し、同じ説明文は作り直さない
+		_, ruleBased := s.(summarizer.RuleBased)
+		glossary, err := loadGlossary(db, database.DefaultLanguage)
+		if err != nil {
+			log.Fatalf("用語集の読み込みに失敗しました: %v", err)
+		}
+		s = summarizer.WithGlossary(s, glossary)
+		if !ruleBased {
+			s = summarizer.WithCache(s, db)
+		}
+		// 説明文が同じ・ほぼ同じ変更に人が書いた要約があればそれを使う
+		memory, err := db.LoadTranslationMemory(database.DefaultLanguage)
+		if err != nil {
+			log.Fatalf("翻訳メモリの読み込みに失敗しました: %v", err)
+		}
+		s = summarizer.WithMemory(s, memory)
+		log.Printf("用語集: %d 件, 翻訳メモリ: %d 件", glossary.Len(), memory.Len())
+		if err := resummarizeChanges(ctx, db, s); err != nil {
+			log.Fatalf("日本語要約の作り直しに失敗しました: %v", err)
+		}
+		return
+	}
+
+	// 用語集・要約の CSV の書き出し・インポート（翻訳者がオフラインで編集するため）
+	if *exportGlossary != "" || *exportTranslations != "" || *importGlossary != "" || *importTranslations != "" {
+		if err := runTranslationFiles(db, *importGlossary, *exportGlossary, *importTranslations, *exportTranslations, *translationLang, *overrideActor); err != nil {
+			log.Fatalf("用語集・要約のファイルの処理に失敗しました: %v", err)
+		}
+		return
+	}
+
+	// マイナーリビジョン JSON の検証（問題があれば終了コード 1）
+	if *validateJSON != "" {
+		validator := importer.NewMinorImporter(db)
+		valid, err := validateMinorRevisionFiles(validator, *validateJSON, !*offline)
+		if err != nil {
+			log.Fatalf("JSONファイルの検証に失敗しました: %v", err)
+		}
+		if !valid {
+			os.Exit(1)
+		}
+		return
+	}
+
+	// リリース間の変更一覧を表示して終了
+	if *diffFrom != "" || *diffTo != "" {
+		if err := printReleaseDiff(db, *diffFrom, *diffTo, *platform, *upcoming); err != nil {
+			log.Fatalf("リリース差分の取得に失敗しました: %v", err)
+		}
+		return
+	}
+
 	// JSONインポート
 	if *importJSON != "" {
 		log.Printf("JSONファイルをインポート中: %s", *importJSON)
-		jsonImporter := importer.NewJSONImporter(db)
-		if err := jsonImporter.ImportMinorRevisions(*importJSON); err != nil {
+		minorImporter := importer.NewMinorImporter(db)
+		minorImporter.SetClassifier(changeClassifier)
+		src, err := fileSource(database.SourceImportJSON, *importJSON)
+		var report importer.MinorImportReport
+		if err == nil {
+			err = runIngestion(db, src, func(runID int) error {
+				report, err = minorImporter.Import(runID, *importJSON)
+				return err
+			})
+		}
+		if err != nil {
 			log.Printf("JSONインポートエラー: %v", err)
 		} else {
-			log.Println("JSONインポート完了")
+			for _, v := range report.Versions {
+				created := ""
+				if v.Created {
+					created = "（リリースを作成）"
+				}
+				log.Printf("  Go %s: %d 件を追加、%d 件をスキップ%s", v.Version, v.Imported, v.Skipped, created)
+			}
+			for _, w := range report.Warnings {
+				log.Printf("  警告 %s", w)
+			}
+			log.Printf("JSONインポート完了（%s 形式）: %d 件を追加、%d 件をスキップ", report.Format, report.Imported(), report.Skipped())
 		}
 		
 		// JSONインポートのみの場合はここで終了
@@ -49,18 +238,77 @@ func main() {
 		}
 	}
 
-	// ベースバージョン作成
+	// OSV（脆弱性データベース）インポート
+	if *importOSV != "" {
+		log.Printf("OSVディレクトリをインポート中: %s", *importOSV)
+		osvImporter := importer.NewOSVImporter(db)
+		src := database.IngestionSource{Type: database.SourceImportOSV, Source: *importOSV}
+		err := runIngestion(db, src, func(runID int) error {
+			return osvImporter.ImportDirectory(runID, *importOSV)
+		})
+		if err != nil {
+			log.Printf("OSVインポートエラー: %v", err)
+		}
+
+		// OSVインポートのみの場合はここで終了
+		if *dataOnly {
+			log.Println("OSVインポート完了。プログラムを終了します。")
+			return
+		}
+	}
+
+	// Markdown のリリースノートのインポート
+	if *importMarkdown != "" {
+		log.Printf("Markdown のリリースノートをインポート中: %s", *importMarkdown)
+		src := database.IngestionSource{Type: database.SourceImportMarkdown, Source: *importMarkdown}
+		err := runIngestion(db, src, func(runID int) error {
+			return importMarkdownData(db, runID, *importMarkdown, *parserOverrides, changeClassifier)
+		})
+		if err != nil {
+			log.Printf("Markdownインポートエラー: %v", err)
+		} else {
+			log.Println("Markdownインポート完了")
+		}
+
+		// Markdownインポートのみの場合はここで終了
+		if *dataOnly {
+			log.Println("Markdownインポート完了。プログラムを終了します。")
+			return
+		}
+	}
+
+	// 上書き設定のインポート（変更の行とは別に保存するため、再取得しても失われない）
+	if *importOverrides != "" {
+		if *overrideActor == "" {
+			log.Fatalf("-override-actor を指定してください")
+		}
+		log.Printf("上書き設定をインポート中: %s", *importOverrides)
+		result, err := importer.ImportOverrides(db, *importOverrides, *overrideActor)
+		if err != nil {
+			log.Printf("上書き設定のインポートエラー: %v", err)
+		} else {
+			log.Printf("上書き設定のインポート完了 (作成: %d, 更新: %d, 変更なし: %d)", result.Created, result.Updated, result.Unchanged)
+		}
+
+		// 上書き設定のインポートのみの場合はここで終了
+		if *dataOnly {
+			log.Println("上書き設定のインポート完了。プログラムを終了します。")
+			return
+		}
+	}
+
+	// ベースエントリの導出
 	if *createBase {
-		log.Println("ベースバージョンエントリを作成中...")
-		if err := importer.CreateBaseVersions(db); err != nil {
-			log.Printf("ベースバージョン作成エラー: %v", err)
+		n, err := db.RefreshBaseEntries()
+		if err != nil {
+			log.Printf("ベースエントリの導出エラー: %v", err)
 		} else {
-			log.Println("ベースバージョン作成完了")
+			log.Printf("ベースエントリを導出しました (%d 件)", n)
 		}
-		
-		// ベースバージョン作成のみの場合はここで終了
+
+		// ベースエントリの導出のみの場合はここで終了
 		if *dataOnly {
-			log.Println("ベースバージョン作成完了。プログラムを終了します。")
+			log.Println("ベースエントリの導出完了。プログラムを終了します。")
 			return
 		}
 	}
@@ -68,7 +316,21 @@ func main() {
 	// データ取得
 	if *refresh || *dataOnly {
 		log.Println("Go言語リリース情報を取得中...")
-		if err := fetchAndStoreData(db); err != nil {
+		src := database.IngestionSource{Type: database.SourceScrape, Source: "https://go.dev/doc/devel/release"}
+		opts := scrapeOptions{
+			Backfill:        *backfill,
+			Prerelease:      *prerelease,
+			ParserOverrides: *parserOverrides,
+			Workers:         *scrapeWorkers,
+			RequestsPerSec:  *scrapeRate,
+			HTTPCache:       *httpCache,
+			Reparse:         *reparse,
+			Classifier:      changeClassifier,
+		}
+		err := runIngestion(db, src, func(runID int) error {
+			return fetchAndStoreData(ctx, db, runID, opts)
+		})
+		if err != nil {
 			log.Printf("データ取得エラー: %v", err)
 		} else {
 			log.Println("データ取得完了")
@@ -81,36 +343,230 @@ func main() {
 		return
 	}
 
+	// ドライランではサーバーを起動しない
+	if *dryRun {
+		return
+	}
+
 	// サーバー起動
 	srv := server.New(db, *port)
+	srv.SetAdminToken(*adminToken)
 	log.Printf("Webサーバーを起動します...")
-	if err := srv.Start(); err != nil {
-		log.Fatalf("サーバー起動に失敗しました: %v", err)
+	if err := srv.Start(ctx); err != nil {
+		log.Fatalf("サーバーの実行に失敗しました: %v", err)
 	}
 }
 
-func fetchAndStoreData(db *database.Database) error {
+// runIngestion は実行記録を作成して fn を実行し、結果に応じて実行記録を閉じる
+// fn は受け取った実行記録の ID を指定して releases・package_changes の行を保存する
+func runIngestion(db *database.Database, src database.IngestionSource, fn func(runID int) error) error {
+	src.ToolVersion = toolVersion()
+	runID, err := db.StartIngestionRun(src)
+	if err != nil {
+		return err
+	}
+
+	status := database.IngestionSucceeded
+	fnErr := fn(runID)
+	if fnErr != nil {
+		status = database.IngestionFailed
+	}
+	if err := db.FinishIngestionRun(runID, status); err != nil {
+		log.Printf("実行記録の更新エラー: %v", err)
+	}
+	log.Printf("実行記録 #%d (%s: %s) を保存しました", runID, src.Type, src.Source)
+	return fnErr
+}
+
+// toolVersion はビルド情報からモジュールのバージョン（なければ VCS リビジョン）を返す
+func toolVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+
+	if info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" {
+			return setting.Value
+		}
+	}
+	return "(devel)"
+}
+
+// fileSource はファイルの内容の SHA-256 を付けた取得元を返す
+func fileSource(sourceType, path string) (database.IngestionSource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return database.IngestionSource{}, err
+	}
+	sum := sha256.Sum256(data)
+	return database.IngestionSource{Type: sourceType, Source: path, Hash: hex.EncodeToString(sum[:])}, nil
+}
+
+// scrapeOptions はスクレイピングの設定
+type scrapeOptions struct {
+	Backfill        bool    // Go 1.0〜1.17 も取得する
+	Prerelease      bool    // 正式リリース前のプレリリースと tip の草稿も取得する
+	ParserOverrides string  // バージョンごとの解析方法を指定する JSON ファイル
+	Workers         int     // 同時に取得するリリースノートの数
+	RequestsPerSec  float64 // go.dev への 1 秒あたりのリクエスト数の上限
+	HTTPCache       string  // 取得したページを保存するディレクトリ（空の場合は使わない）
+	Reparse         bool    // 更新されていないリリースノートも解析し直す
+	Classifier      *classifier.Classifier // 変更種別の判定規則
+}
+
+func fetchAndStoreData(ctx context.Context, db *database.Database, runID int, opts scrapeOptions) error {
 	// スクレイパーの初期化
 	releaseScraper := scraper.NewReleaseScraper()
+	releaseScraper.SetConcurrency(opts.Workers, opts.RequestsPerSec)
+	releaseScraper.SetClassifier(opts.Classifier)
+	if opts.ParserOverrides != "" {
+		if err := releaseScraper.LoadParserOverrides(opts.ParserOverrides); err != nil {
+			return err
+		}
+	}
+	if opts.HTTPCache != "" {
+		cache, err := scraper.NewHTTPCache(opts.HTTPCache)
+		if err != nil {
+			return err
+		}
+		releaseScraper.SetCache(cache)
+		// 保存済みのリリースはリリースノートが更新されていなければ解析・保存を省略する
+		if !opts.Reparse {
+			releaseScraper.SkipUnchanged = func(version string) bool {
+				_, err := db.GetReleaseID(version)
+				return err == nil
+			}
+		}
+	}
 	
-	// 対象バージョンの取得
+	// 対象バージョンの取得（backfill の場合は Go 1.0〜1.17 を先頭に追加）
 	versions := releaseScraper.GetTargetVersions()
+	if opts.Backfill {
+		versions = append(releaseScraper.GetBackfillVersions(), versions...)
+	}
+	if opts.Prerelease {
+		prereleases, err := releaseScraper.GetPrereleaseVersions(ctx)
+		if err != nil {
+			log.Printf("プレリリースの一覧を取得できませんでした: %v", err)
+		}
+		versions = append(versions, prereleases...)
+	}
 	log.Printf("対象バージョン: %v", versions)
 
 	// リリース情報の取得
-	releases, err := releaseScraper.GetReleaseInfo(versions)
+	releases, err := releaseScraper.GetReleaseInfo(ctx, versions)
 	if err != nil {
 		return err
 	}
 
-	log.Printf("取得したリリース数: %d", len(releases))
+	cacheHits := releaseScraper.CacheHits()
+	if err := db.RecordIngestionCacheHits(runID, cacheHits); err != nil {
+		log.Printf("キャッシュ使用数の記録エラー: %v", err)
+	}
+
+	// 更新されていないリリースは保存済みのデータをそのまま使う
+	// 取得できなかった過去リリースは診断だけを保存する
+	var parsed []scraper.ReleaseInfo
+	failed := 0
+	for _, release := range releases {
+		switch {
+		case release.FetchFailed:
+			failed++
+			if err := db.SaveIngestionDiagnostics(runID, toDatabaseDiagnostics(release.Diagnostics)); err != nil {
+				log.Printf("診断保存エラー (Go %s): %v", release.Version, err)
+			}
+		case !release.NotModified:
+			parsed = append(parsed, release)
+		}
+	}
+	log.Printf("取得したリリース数: %d (更新なしで省略: %d, 取得失敗: %d, キャッシュ使用: %d)", len(releases), len(releases)-len(parsed)-failed, failed, cacheHits)
+	releases = parsed
+
+	saveReleases(db, runID, releases)
+
+	// 解析結果の表示
+	analyzer := analyzer.NewStdLibAnalyzer()
+	vizData, err := analyzer.AnalyzeReleases(releases)
+	if err != nil {
+		log.Printf("解析エラー: %v", err)
+		return err
+	}
+
+	log.Printf("解析完了:")
+	log.Printf("  - 総パッケージ数: %d", len(vizData.Packages))
+	log.Printf("  - 総バージョン数: %d", len(vizData.Versions))
+
+	// 統計情報の表示
+	stats := analyzer.GetPackageStats()
+	log.Printf("統計情報: %+v", stats)
+
+	return nil
+}
+
+// importMarkdownData は Markdown のリリースノートを解析して保存する
+// 保存済みのリリースはリリース日を引き継ぐ（Markdown にはリリース日がないため）
+func importMarkdownData(db *database.Database, runID int, dir, parserOverrides string, c *classifier.Classifier) error {
+	releaseScraper := scraper.NewReleaseScraper()
+	releaseScraper.SetClassifier(c)
+	if parserOverrides != "" {
+		if err := releaseScraper.LoadParserOverrides(parserOverrides); err != nil {
+			return err
+		}
+	}
+
+	releases, err := releaseScraper.LoadMarkdownReleaseNotes(dir)
+	if err != nil {
+		return err
+	}
+
+	existing, err := db.GetAllReleases()
+	if err != nil {
+		return err
+	}
+	for i := range releases {
+		for _, r := range existing {
+			if r.Version == releases[i].Version {
+				releases[i].ReleaseDate = r.ReleaseDate
+				break
+			}
+		}
+	}
+
+	log.Printf("Markdown から読み込んだリリース数: %d", len(releases))
+	saveReleases(db, runID, releases)
+	return nil
+}
+
+// saveReleases はリリースと変更・GODEBUG 設定への言及・診断を保存し、プレリリースの変更を正式リリースと突き合わせる
+func saveReleases(db *database.Database, runID int, releases []scraper.ReleaseInfo) {
+	existing, err := db.GetAllReleases()
+	if err != nil {
+		log.Printf("保存済みのリリースの取得エラー: %v", err)
+	}
 
-	// データベースに保存
 	for _, release := range releases {
+		// プレリリースのリリース日は最初に取得した日のまま保つ
+		if release.Prerelease() {
+			for _, r := range existing {
+				if r.Version == release.Version {
+					release.ReleaseDate = r.ReleaseDate
+					break
+				}
+			}
+		}
+
 		log.Printf("保存中: Go %s", release.Version)
+
+		if err := db.SaveIngestionDiagnostics(runID, toDatabaseDiagnostics(release.Diagnostics)); err != nil {
+			log.Printf("診断保存エラー (Go %s): %v", release.Version, err)
+		}
 		
 		// リリース情報を保存
-		releaseID, err := db.SaveRelease(release.Version, release.ReleaseDate, release.URL)
+		releaseID, err := db.SaveRelease(runID, release.Version, release.ReleaseDate, release.URL)
 		if err != nil {
 			log.Printf("リリース保存エラー (Go %s): %v", release.Version, err)
 			continue
@@ -122,34 +578,399 @@ func fetchAndStoreData(db *database.Database) error {
 				continue
 			}
 			
-			err := db.SavePackageChangeWithSummary(releaseID, change.Package, change.ChangeType, change.Description, change.SummaryJa)
+			_, err := db.InsertPackageChange(database.PackageChange{
+				ReleaseID:       releaseID,
+				Package:         change.Package,
+				ChangeType:      change.ChangeType,
+				ChangeTypeConfidence: change.ChangeTypeConfidence,
+				Description:     change.Description,
+				DescriptionHTML: change.DescriptionHTML,
+				Excerpt:         change.Excerpt,
+				Summary:         change.SummaryJa,
+				SummaryLang:     database.DefaultLanguage,
+				Links:           toDatabaseLinks(change.Links),
+				Area:             change.Area,
+				Subheading:       change.Subheading,
+				Experiment:       change.Experiment,
+				ExperimentStatus: change.ExperimentStatus,
+				Platforms:        change.Platforms,
+				IngestionRunID:   runID,
+			})
 			if err != nil {
 				log.Printf("パッケージ変更保存エラー (%s): %v", change.Package, err)
 			}
 		}
 		
-		log.Printf("Go %s の保存完了 (変更数: %d)", release.Version, len(release.Changes))
+		// GODEBUG 設定への言及を保存
+		for _, godebug := range release.Godebugs {
+			err := db.SaveGodebugEvent(runID, database.GodebugEvent{
+				Name:        godebug.Name,
+				Version:     godebug.Version,
+				Kind:        godebug.Kind,
+				Value:       godebug.Value,
+				Package:     godebug.Package,
+				Description: godebug.Description,
+			})
+			if err != nil {
+				log.Printf("GODEBUG設定保存エラー (%s): %v", godebug.Name, err)
+			}
+		}
+
+		log.Printf("Go %s の保存完了 (変更数: %d, 診断数: %d)", release.Version, len(release.Changes), len(release.Diagnostics))
 	}
 
-	// 解析結果の表示
-	analyzer := analyzer.NewStdLibAnalyzer()
-	vizData, err := analyzer.AnalyzeReleases(releases)
+	result, err := db.ReconcilePrereleases()
+	if err != nil {
+		log.Printf("プレリリースの突き合わせエラー: %v", err)
+	} else if len(result.Releases) > 0 {
+		log.Printf("プレリリース %v を正式リリースと突き合わせました (対応: %d, 取り下げ: %d)", result.Releases, result.Matched, result.Dropped)
+	}
+}
+
+// reclassifyChanges は保存済みの変更の種別を判定し直す
+// 脆弱性データベースと対応付けた変更はセキュリティ修正のまま残し、日本語要約は自動生成したものだけ作り直す
+func reclassifyChanges(db *database.Database, c *classifier.Classifier) error {
+	changes, err := db.GetAllPackageChanges()
+	if err != nil {
+		return err
+	}
+	vulnIDs, err := db.GetChangeVulnerabilityIDs()
 	if err != nil {
-		log.Printf("解析エラー: %v", err)
 		return err
 	}
 
-	log.Printf("解析完了:")
-	log.Printf("  - 総パッケージ数: %d", len(vizData.Packages))
-	log.Printf("  - 総バージョン数: %d", len(vizData.Versions))
+	var updates []database.ChangeClassification
+	counts := make(map[string]int)
+	for _, change := range changes {
+		// スクレイパーと同じく抜粋を対象にする（抜粋のない行は説明文）
+		text := change.Excerpt
+		if text == "" {
+			text = change.Description
+		}
 
-	// 統計情報の表示
-	stats := analyzer.GetPackageStats()
-	log.Printf("統計情報: %+v", stats)
+		result := c.Classify(text)
+		if len(vulnIDs[change.ID]) > 0 {
+			result = classifier.Result{Type: classifier.SecurityFix, Confidence: 1}
+		}
+
+		// 人が確認した要約は作り直さない
+		summary := change.Summary
+		if change.SummaryStatus != database.TranslationReviewed && classifier.IsGeneratedSummary(text, change.ChangeType, summary) {
+			summary = classifier.SummaryJa(text, result.Type)
+		}
+
+		if result.Type == change.ChangeType && result.Confidence == change.ChangeTypeConfidence && summary == change.Summary {
+			continue
+		}
+		if result.Type != change.ChangeType {
+			counts[change.ChangeType+" -> "+result.Type]++
+		}
+		updates = append(updates, database.ChangeClassification{
+			ID:         change.ID,
+			ChangeType: result.Type,
+			Confidence: result.Confidence,
+			SummaryJa:  summary,
+		})
+	}
+
+	if err := db.UpdateChangeClassifications(updates); err != nil {
+		return err
+	}
+
+	transitions := make([]string, 0, len(counts))
+	for t := range counts {
+		transitions = append(transitions, t)
+	}
+	sort.Strings(transitions)
+	for _, t := range transitions {
+		log.Printf("  %s: %d 件", t, counts[t])
+	}
+	log.Printf("変更種別を再分類しました (対象: %d 件, 更新: %d 件)", len(changes), len(updates))
+	return nil
+}
+
+// loadGlossary は lang の用語集を読み込む
+func loadGlossary(db *database.Database, lang string) (*summarizer.Glossary, error) {
+	entries, err := db.GetGlossary(lang)
+	if err != nil {
+		return nil, err
+	}
+	terms := make([]summarizer.Term, 0, len(entries))
+	for _, e := range entries {
+		terms = append(terms, summarizer.Term{Term: e.Term, Translation: e.Translation})
+	}
+	return summarizer.NewGlossary(terms), nil
+}
+
+// runTranslationFiles は用語集・要約の CSV ファイルのインポートと書き出しを行う（インポートを先に行う）
+func runTranslationFiles(db *database.Database, importGlossary, exportGlossary, importTranslations, exportTranslations, lang, actor string) error {
+	if (importGlossary != "" || importTranslations != "") && actor == "" {
+		return fmt.Errorf("-override-actor を指定してください")
+	}
+
+	if importGlossary != "" {
+		result, err := importer.ImportGlossary(db, importGlossary, actor)
+		if err != nil {
+			return err
+		}
+		log.Printf("用語集をインポートしました (作成: %d, 更新: %d, 変更なし: %d)", result.Created, result.Updated, result.Unchanged)
+	}
+	if importTranslations != "" {
+		result, err := importer.ImportTranslations(db, importTranslations, actor)
+		if err != nil {
+			return err
+		}
+		log.Printf("要約をインポートしました (保存: %d, 変更なし: %d, 一致する変更なし: %d)", result.Saved, result.Unchanged, result.Unmatched)
+	}
+
+	if exportGlossary != "" {
+		n, err := importer.ExportGlossary(db, exportGlossary, lang)
+		if err != nil {
+			return err
+		}
+		log.Printf("用語集を書き出しました: %s (%d 件)", exportGlossary, n)
+	}
+	if exportTranslations != "" {
+		if lang == "" {
+			return fmt.Errorf("-export-translations には -translation-lang を指定してください")
+		}
+		n, err := importer.ExportTranslations(db, exportTranslations, lang)
+		if err != nil {
+			return err
+		}
+		log.Printf("要約を書き出しました: %s (%d 件)", exportTranslations, n)
+	}
+	return nil
+}
+
+// validateMinorRevisionFiles は path のマイナーリビジョン JSON（ディレクトリの場合は中の *.json）を検証して結果を表示し、
+// すべてのファイルにエラーがないかを返す
+func validateMinorRevisionFiles(validator *importer.MinorImporter, path string, checkLinks bool) (bool, error) {
+	files := []string{path}
+	if info, err := os.Stat(path); err != nil {
+		return false, err
+	} else if info.IsDir() {
+		if files, err = filepath.Glob(filepath.Join(path, "*.json")); err != nil {
+			return false, err
+		}
+		if len(files) == 0 {
+			return false, fmt.Errorf("%s に JSON ファイルがありません", path)
+		}
+	}
+
+	valid := true
+	for _, file := range files {
+		v, err := validator.Validate(file, checkLinks)
+		if err != nil {
+			return false, err
+		}
+		if v.Valid() {
+			fmt.Printf("OK  %s（%s 形式、%d バージョン・%d 件）\n", file, v.Format, v.Versions, v.Changes)
+		} else {
+			valid = false
+			fmt.Printf("NG  %s（エラー %d 件）\n", file, len(v.Errors))
+		}
+		for _, p := range v.Errors {
+			fmt.Printf("    エラー %s\n", p)
+		}
+		for _, p := range v.Warnings {
+			fmt.Printf("    警告   %s\n", p)
+		}
+		if v.LinksSkipped != "" {
+			fmt.Printf("    リンク未確認: %s\n", v.LinksSkipped)
+		} else if v.LinksChecked > 0 {
+			fmt.Printf("    リンク %d 件を確認しました\n", v.LinksChecked)
+		}
+	}
+	return valid, nil
+}
+
+// resummarizeChanges は保存済みの変更の日本語要約を s で作り直す
+// 要約を作れなかった変更は元の要約のまま残す
+func resummarizeChanges(ctx context.Context, db *database.Database, s summarizer.Summarizer) error {
+	changes, err := db.GetAllPackageChanges()
+	if err != nil {
+		return err
+	}
+
+	var updates []database.ChangeSummary
+	failed := 0
+	for i, change := range changes {
+		if change.Description == "" {
+			continue
+		}
+		// 人が確認した要約は作り直さない
+		if change.SummaryStatus == database.TranslationReviewed {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		summary, err := s.Summarize(ctx, summarizer.Input{
+			Package:     change.Package,
+			ChangeType:  change.ChangeType,
+			Description: change.Description,
+			Excerpt:     change.Excerpt,
+		})
+		if err != nil {
+			log.Printf("要約の作成エラー (#%d %s): %v", change.ID, change.Package, err)
+			failed++
+			continue
+		}
+		if summary != change.Summary {
+			updates = append(updates, database.ChangeSummary{ID: change.ID, SummaryJa: summary})
+		}
+		if (i+1)%100 == 0 {
+			log.Printf("要約を作成中: %d / %d 件", i+1, len(changes))
+		}
+	}
+
+	if err := db.UpdateChangeSummaries(updates); err != nil {
+		return err
+	}
+	log.Printf("日本語要約を作り直しました (%s, 対象: %d 件, 更新: %d 件, 失敗: %d 件)", s.Name(), len(changes), len(updates), failed)
+	return nil
+}
+
+// printGodebugFlips はモジュールの go バージョンからツールチェーンまでに切り替わる GODEBUG 設定を表示する
+func printGodebugFlips(db *database.Database, goVersion, toolchain string) error {
+	if goVersion == "" || toolchain == "" {
+		return fmt.Errorf("-godebug-go と -godebug-toolchain の両方を指定してください")
+	}
+
+	flips, err := db.GetGodebugFlips(goVersion, toolchain)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("go %s -> %s で切り替わる GODEBUG 設定: %d 件\n", goVersion, toolchain, len(flips))
+	for _, flip := range flips {
+		restore := ""
+		if flip.Value != "" {
+			restore = fmt.Sprintf(" (旧動作: GODEBUG=%s=%s)", flip.Name, flip.Value)
+		}
+		fmt.Printf("  Go %-7s %-16s %-24s %s%s\n", flip.Version, flip.Kind, flip.Name, flip.Package, restore)
+	}
+	return nil
+}
+
+// printDryRunDiff は本番のデータベースとドライランの結果の差分を表示する
+func printDryRunDiff(live, preview *database.Database, format string) {
+	diff, err := database.CompareDatabases(live, preview)
+	if err != nil {
+		log.Printf("ドライランの差分取得エラー: %v", err)
+		return
+	}
+
+	if format == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(diff); err != nil {
+			log.Printf("ドライランの差分出力エラー: %v", err)
+		}
+		return
+	}
+
+	fmt.Printf("ドライラン結果: リリース 追加 %d / 変更 %d / 削除 %d、変更 追加 %d / 変更 %d / 削除 %d\n",
+		len(diff.AddedReleases), len(diff.ChangedReleases), len(diff.RemovedReleases),
+		len(diff.AddedChanges), len(diff.ChangedChanges), len(diff.RemovedChanges))
+	if diff.Empty() {
+		fmt.Println("変更はありません")
+		return
+	}
+
+	for _, r := range diff.AddedReleases {
+		fmt.Printf("+ Go %-8s %s %s\n", r.Version, r.ReleaseDate.Format("2006-01-02"), r.URL)
+	}
+	for _, u := range diff.ChangedReleases {
+		fmt.Printf("~ Go %-8s %s %s -> %s %s\n", u.After.Version,
+			u.Before.ReleaseDate.Format("2006-01-02"), u.Before.URL, u.After.ReleaseDate.Format("2006-01-02"), u.After.URL)
+	}
+	for _, r := range diff.RemovedReleases {
+		fmt.Printf("- Go %-8s %s %s\n", r.Version, r.ReleaseDate.Format("2006-01-02"), r.URL)
+	}
+
+	for _, c := range diff.AddedChanges {
+		fmt.Printf("+ Go %-8s %-24s %-12s %s\n", c.Version, c.Package, c.ChangeType, scraper.MakeExcerpt(c.Description))
+	}
+	for _, u := range diff.ChangedChanges {
+		fmt.Printf("~ Go %-8s %-24s %-12s %s\n", u.Version, u.After.Package, u.After.ChangeType, scraper.MakeExcerpt(u.After.Description))
+		if u.Before.ChangeType != u.After.ChangeType {
+			fmt.Printf("    変更種別: %s -> %s\n", u.Before.ChangeType, u.After.ChangeType)
+		}
+		if u.Before.Description != u.After.Description {
+			fmt.Printf("    変更前: %s\n", scraper.MakeExcerpt(u.Before.Description))
+		}
+	}
+	for _, c := range diff.RemovedChanges {
+		fmt.Printf("- Go %-8s %-24s %-12s %s\n", c.Version, c.Package, c.ChangeType, scraper.MakeExcerpt(c.Description))
+	}
+}
+
+// printReleaseDiff は from から to へ引き上げたときに入る変更を対象プラットフォームで絞り込んで表示する
+func printReleaseDiff(db *database.Database, from, to, platformParam string, includeUpcoming bool) error {
+	if from == "" || to == "" {
+		return fmt.Errorf("-diff-from と -diff-to の両方を指定してください")
+	}
 
+	platforms, err := platform.ParseList(platformParam)
+	if err != nil {
+		return err
+	}
+
+	diff, err := db.GetReleaseDiff(from, to, database.VisualizationOptions{
+		DescriptionFormat: database.DescriptionFormatExcerpt,
+		Platforms:         platforms,
+		IncludeUpcoming:   includeUpcoming,
+	})
+	if err != nil {
+		return err
+	}
+
+	target := "全プラットフォーム"
+	if len(platforms) > 0 {
+		target = platformParam
+	}
+	fmt.Printf("Go %s -> %s の変更（対象: %s）\n", from, to, target)
+	for _, release := range diff.Releases {
+		label := ""
+		if release.Prerelease {
+			label = " [プレリリース]"
+		}
+		fmt.Printf("\nGo %s%s (%d 件)\n", release.Version, label, len(release.Changes))
+		for _, change := range release.Changes {
+			scope := ""
+			if len(change.Platforms) > 0 {
+				scope = fmt.Sprintf(" [%s]", database.FormatPlatforms(change.Platforms))
+			}
+			fmt.Printf("  %-24s %-12s%s %s\n", change.Package, change.ChangeType, scope, change.Description)
+		}
+	}
 	return nil
 }
 
+func toDatabaseDiagnostics(diagnostics []scraper.Diagnostic) []database.IngestionDiagnostic {
+	var result []database.IngestionDiagnostic
+	for _, diag := range diagnostics {
+		result = append(result, database.IngestionDiagnostic{
+			Version: diag.Version,
+			Kind:    diag.Kind,
+			Subject: diag.Subject,
+			Detail:  diag.Detail,
+		})
+	}
+	return result
+}
+
+func toDatabaseLinks(links []scraper.ChangeLink) []database.ChangeLink {
+	var result []database.ChangeLink
+	for _, link := range links {
+		result = append(result, database.ChangeLink{URL: link.URL, Text: link.Text, Kind: link.Kind})
+	}
+	return result
+}
+
 func init() {
 	// ログの設定
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go-ver-trace/internal/database"
+	"go-ver-trace/internal/importer"
+)
+
+// captureStdout は f の実行中に標準出力へ書かれた内容を返す
+func captureStdout(t *testing.T, f func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	done := make(chan string)
+	go func() {
+		out, _ := io.ReadAll(r)
+		done <- string(out)
+	}()
+	f()
+	w.Close()
+	return <-done
+}
+
+// TestValidateMinorRevisionFiles は -validate-json がスキーマに合わないファイルを NG とし、
+// 違反した制約ごとにエラーを表示することを確認する
+func TestValidateMinorRevisionFiles(t *testing.T) {
+	db, err := database.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	validator := importer.NewMinorImporter(db)
+
+	var valid bool
+	out := captureStdout(t, func() {
+		valid, err = validateMinorRevisionFiles(validator, "testdata/invalid-minor.json", false)
+	})
+	if err != nil {
+		t.Fatalf("validateMinorRevisionFiles: %v", err)
+	}
+	if valid {
+		t.Errorf("invalid-minor.json is valid, want invalid\n%s", out)
+	}
+	for _, want := range []string{
+		"NG  testdata/invalid-minor.json（エラー 4 件）",
+		`[0].links[0]: "go.dev/issue/69139" does not match pattern "^https?://"`,
+		`[1].release_date: "2024-13-01" has invalid date format`,
+		`[1].version: "go1.23" does not match pattern`,
+		`[2]: unknown field "issue"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output does not contain %q\n%s", want, out)
+		}
+	}
+
+	out = captureStdout(t, func() {
+		valid, err = validateMinorRevisionFiles(validator, "../../minor_revision_updates", false)
+	})
+	if err != nil || !valid {
+		t.Errorf("minor_revision_updates: valid = %v, err = %v\n%s", valid, err, out)
+	}
+}
@@ -7,7 +7,8 @@ interface CustomNodeData {
   version: string;
   changeType: string;
   description: string;
-  summaryJa: string;
+  summary: string;
+  summaryStatus?: string;
   releaseDate: string;
 }
 
@@ -49,6 +50,23 @@ const CustomNode: React.FC<CustomNodeProps> = ({ data, style }) => {
       <div>
         {data.label}
       </div>
+      {/* 未確認の自動生成の要約 */}
+      {data.summaryStatus === 'machine' && (
+        <div
+          title="自動生成の要約（未確認）"
+          style={{
+            marginTop: '4px',
+            fontSize: '10px',
+            color: '#92400e',
+            background: '#fef3c7',
+            borderRadius: '4px',
+            padding: '0 4px',
+            display: 'inline-block'
+          }}
+        >
+          未確認
+        </div>
+      )}
 
       {/* 右側のハンドル（出力） */}
       <Handle
@@ -3,6 +3,10 @@ import React, { useState } from 'react';
 interface FilterControlsProps {
   packages: string[];
   changeTypes: string[];
+  selectedAreas: string[];
+  onAreaChange: (areas: string[]) => void;
+  includeUpcoming: boolean;
+  onIncludeUpcomingChange: (includeUpcoming: boolean) => void;
   selectedPackages: string[];
   selectedChangeTypes: string[];
   onPackageChange: (packages: string[]) => void;
@@ -14,6 +18,10 @@ interface FilterControlsProps {
 const FilterControls: React.FC<FilterControlsProps> = ({
   packages,
   changeTypes,
+  selectedAreas,
+  onAreaChange,
+  includeUpcoming,
+  onIncludeUpcomingChange,
   selectedPackages,
   selectedChangeTypes,
   onPackageChange,
@@ -32,6 +40,25 @@ const FilterControls: React.FC<FilterControlsProps> = ({
     'Removed': '削除',
   };
 
+  // 領域の日本語ラベル
+  const areaLabels: Record<string, string> = {
+    'language': '言語仕様',
+    'tools': 'ツール',
+    'runtime': 'ランタイム',
+    'compiler': 'コンパイラ',
+    'linker': 'リンカ',
+    'ports': 'ポート',
+    'stdlib': '標準ライブラリ',
+  };
+
+  const handleAreaToggle = (area: string) => {
+    if (selectedAreas.includes(area)) {
+      onAreaChange(selectedAreas.filter(a => a !== area));
+    } else {
+      onAreaChange([...selectedAreas, area]);
+    }
+  };
+
   // パッケージ検索フィルター
   const filteredPackages = packages.filter(pkg =>
     pkg.toLowerCase().includes(packageSearch.toLowerCase())
@@ -70,6 +97,8 @@ const FilterControls: React.FC<FilterControlsProps> = ({
   };
 
   const clearAllFilters = () => {
+    onAreaChange([]);
+    onIncludeUpcomingChange(false);
     onChangeTypeChange([]);
     onPackageChange([]);
     setPackageSearch('');
@@ -131,6 +160,41 @@ const FilterControls: React.FC<FilterControlsProps> = ({
 
       {!isCollapsed && (
         <>
+          {/* 領域フィルター */}
+          <div className="filter-group">
+            <label className="filter-label">領域</label>
+            <div className="filter-checkboxes">
+              {Object.keys(areaLabels).map(area => (
+                <div key={area} className="filter-checkbox">
+                  <input
+                    type="checkbox"
+                    id={`area-${area}`}
+                    checked={selectedAreas.includes(area)}
+                    onChange={() => handleAreaToggle(area)}
+                  />
+                  <label htmlFor={`area-${area}`}>
+                    {areaLabels[area]}
+                  </label>
+                </div>
+              ))}
+            </div>
+          </div>
+
+          {/* プレリリース（正式リリース前の変更） */}
+          <div className="filter-group">
+            <div className="filter-checkbox">
+              <input
+                type="checkbox"
+                id="include-upcoming"
+                checked={includeUpcoming}
+                onChange={() => onIncludeUpcomingChange(!includeUpcoming)}
+              />
+              <label htmlFor="include-upcoming">
+                リリース予定（rc・tip）の変更を含める
+              </label>
+            </div>
+          </div>
+
           {/* 変更種別フィルター */}
           <div className="filter-group">
             <div style={{ 
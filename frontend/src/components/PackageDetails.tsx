@@ -273,7 +273,14 @@ const getChangeTypeDisplayName = (changeType: string): string => {
     case 'Compatibility':
       return '互換性改善';
     case 'Security Enhancement':
+    case 'Hardening':
       return 'セキュリティ強化';
+    case 'Behavior Change':
+      return '動作変更';
+    case 'Performance':
+      return '性能改善';
+    case 'Documentation':
+      return 'ドキュメント';
     default:
       return changeType;
   }
@@ -330,11 +337,24 @@ const getChangeTypeBadgeStyle = (changeType: string): React.CSSProperties => {
         border: '1px solid #bbf7d0',
       };
     case 'Security Enhancement':
+    case 'Hardening':
       return {
         backgroundColor: '#fef7ff',
         color: '#86198f',
         border: '1px solid #f5d0fe',
       };
+    case 'Behavior Change':
+      return {
+        backgroundColor: '#ffedd5',
+        color: '#9a3412',
+        border: '1px solid #fed7aa',
+      };
+    case 'Performance':
+      return {
+        backgroundColor: '#ecfeff',
+        color: '#155e75',
+        border: '1px solid #a5f3fc',
+      };
     default:
       return {
         backgroundColor: '#f8fafc',
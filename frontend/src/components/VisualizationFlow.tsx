@@ -35,7 +35,10 @@ import CustomNode from './CustomNode';
 import GroupNode from './GroupNode';
 
 const VisualizationFlow: React.FC = () => {
-  const { data, loading, error, refetch } = useVisualizationData();
+  // 領域フィルター（サーバー側で絞り込む）
+  const [selectedAreas, setSelectedAreas] = useState<string[]>([]);
+  const [includeUpcoming, setIncludeUpcoming] = useState(false);
+  const { data, loading, error, refetch } = useVisualizationData(selectedAreas, includeUpcoming);
 
   // フィルター状態
   const [selectedChangeTypes, setSelectedChangeTypes] = useState<string[]>([]);
@@ -212,6 +215,10 @@ const VisualizationFlow: React.FC = () => {
           <FilterControls
             packages={allPackages}
             changeTypes={allChangeTypes}
+            selectedAreas={selectedAreas}
+            onAreaChange={setSelectedAreas}
+            includeUpcoming={includeUpcoming}
+            onIncludeUpcomingChange={setIncludeUpcoming}
             selectedPackages={selectedPackages}
             selectedChangeTypes={selectedChangeTypes}
             onPackageChange={setSelectedPackages}
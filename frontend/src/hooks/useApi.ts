@@ -3,7 +3,7 @@ import { VisualizationData, Release, PackageChange } from "../types";
 
 const API_BASE_URL = "http://localhost:8080/api";
 
-export const useVisualizationData = () => {
+export const useVisualizationData = (areas: string[] = [], includeUpcoming = false) => {
   const [data, setData] = useState<VisualizationData | null>(null);
   const [loading, setLoading] = useState(true);
   const [error, setError] = useState<string | null>(null);
@@ -13,7 +13,15 @@ export const useVisualizationData = () => {
       setLoading(true);
       setError(null);
 
-      const response = await fetch(`${API_BASE_URL}/visualization`);
+      const params = new URLSearchParams();
+      if (areas.length > 0) {
+        params.set("area", areas.join(","));
+      }
+      if (includeUpcoming) {
+        params.set("upcoming", "true");
+      }
+      const query = params.toString() ? `?${params.toString()}` : "";
+      const response = await fetch(`${API_BASE_URL}/visualization${query}`);
       if (!response.ok) {
         throw new Error(`HTTP error! status: ${response.status}`);
       }
@@ -32,7 +40,7 @@ export const useVisualizationData = () => {
 
   useEffect(() => {
     fetchData();
-  }, []);
+  }, [areas.join(","), includeUpcoming]);
 
   const refetch = () => {
     fetchData();
@@ -3,33 +3,100 @@ export interface Release {
   version: string;
   release_date: string;
   url: string;
+  prerelease: boolean;
+  ingestion_run_id?: number;
   created_at: string;
 }
 
+export type Area = 'language' | 'tools' | 'runtime' | 'compiler' | 'linker' | 'ports' | 'stdlib';
+
+export interface ChangeLink {
+  url: string;
+  text: string;
+  kind: 'doc' | 'issue' | 'cl' | 'proposal' | 'external';
+}
+
+// 変更種別（'Test Fix' と 'Security Enhancement' は再分類前のデータに残る以前の種別）
+export type ChangeType =
+  | 'Added'
+  | 'Modified'
+  | 'Behavior Change'
+  | 'Performance'
+  | 'Deprecated'
+  | 'Removed'
+  | 'Bug Fix'
+  | 'Security Fix'
+  | 'Compatibility'
+  | 'Hardening'
+  | 'Documentation'
+  | 'Test Fix'
+  | 'Security Enhancement';
+
 export interface PackageChange {
   id: number;
   release_id: number;
   package: string;
-  change_type: 'Added' | 'Modified' | 'Deprecated' | 'Removed' | 'Bug Fix' | 'Security Fix' | 'Test Fix' | 'Compatibility' | 'Security Enhancement';
+  change_type: ChangeType;
+  change_type_confidence?: number;
   description: string;
-  summary_ja: string;
+  description_html?: string;
+  excerpt: string;
+  summary: string;
+  summary_lang?: string;
+  summary_status?: SummaryStatus;
   source_url?: string;
+  links?: ChangeLink[];
+  area: Area;
+  subheading: string;
+  experiment?: string;
+  experiment_status?: ExperimentStatus;
+  platforms?: Platform[];
+  ingestion_run_id?: number;
+  ga_change_id?: number;
+  override_id?: number;
   created_at: string;
 }
 
+export interface Platform {
+  goos?: string;
+  goarch?: string;
+}
+
+// 要約の確認状態（machine は未確認の自動生成、英語の説明文の抜粋の場合はなし）
+export type SummaryStatus = 'machine' | 'reviewed' | 'rejected';
+
+export type ExperimentStatus = 'experimental' | 'default_on' | 'removed';
+
+export interface PackageExperiment {
+  experiment: string;
+  status: ExperimentStatus;
+}
+
 export interface VisualizationData {
   releases: Release[];
   packages: string[];
   package_evolution: Record<string, PackageVersionChange[]>;
+  package_experiments?: Record<string, PackageExperiment>;
 }
 
 export interface PackageVersionChange {
   version: string;
   release_date: string;
-  change_type: 'Added' | 'Modified' | 'Deprecated' | 'Removed' | 'Bug Fix' | 'Security Fix' | 'Test Fix' | 'Compatibility' | 'Security Enhancement';
+  change_type: ChangeType;
+  change_type_confidence?: number;
   description: string;
-  summary_ja: string;
+  excerpt?: string;
+  summary: string;
+  summary_lang?: string;
+  summary_status?: SummaryStatus;
   source_url?: string;
+  links?: ChangeLink[];
+  area?: Area;
+  subheading?: string;
+  experiment?: string;
+  experiment_status?: ExperimentStatus;
+  platforms?: string[];
+  prerelease?: boolean;
 }
 
 import { Node, Edge, MarkerType } from 'reactflow';
@@ -38,9 +105,10 @@ export interface FlowNodeData {
   label: string;
   package: string;
   version: string;
-  changeType: 'Added' | 'Modified' | 'Deprecated' | 'Removed' | 'Bug Fix' | 'Security Fix' | 'Test Fix' | 'Compatibility' | 'Security Enhancement';
+  changeType: ChangeType;
   description: string;
-  summaryJa: string;
+  summary: string;
+  summaryStatus?: SummaryStatus;
   releaseDate: string;
   sourceUrl?: string;
 }
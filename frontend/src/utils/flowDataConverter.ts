@@ -190,6 +190,20 @@ export const getNodeStyle = (changeType: string): React.CSSProperties => {
         borderColor: "#65a30d",
         color: "#365314",
       };
+    case "Behavior Change":
+      return {
+        ...baseStyle,
+        backgroundColor: "#fff7ed",
+        borderColor: "#ea580c",
+        color: "#9a3412",
+      };
+    case "Performance":
+      return {
+        ...baseStyle,
+        backgroundColor: "#ecfeff",
+        borderColor: "#0891b2",
+        color: "#155e75",
+      };
     default:
       return {
         ...baseStyle,
@@ -250,6 +264,16 @@ export const getEdgeStyle = (changeType: string): React.CSSProperties => {
         ...baseStyle,
         stroke: "#65a30d",
       };
+    case "Behavior Change":
+      return {
+        ...baseStyle,
+        stroke: "#ea580c",
+      };
+    case "Performance":
+      return {
+        ...baseStyle,
+        stroke: "#0891b2",
+      };
     default:
       return {
         ...baseStyle,
@@ -276,6 +300,10 @@ export const getChangeTypeColor = (changeType: string): string => {
       return "#0ea5e9";
     case "Test Fix":
       return "#65a30d";
+    case "Behavior Change":
+      return "#ea580c";
+    case "Performance":
+      return "#0891b2";
     default:
       return "#94a3b8";
   }
@@ -425,7 +453,8 @@ function createVersionNodes(
           version: change.version,
           changeType: change.change_type,
           description: change.description,
-          summaryJa: change.summary_ja,
+          summary: change.summary,
+          summaryStatus: change.summary_status,
           releaseDate: change.release_date,
         },
         style: getNodeStyle(change.change_type),
@@ -471,7 +500,8 @@ function createVersionNodes(
           version: change.version,
           changeType: change.change_type,
           description: change.description,
-          summaryJa: change.summary_ja,
+          summary: change.summary,
+          summaryStatus: change.summary_status,
           releaseDate: change.release_date,
         },
         style: getNodeStyle(change.change_type),
@@ -655,7 +685,7 @@ function createGroupNodes(
         version: majorVersion.version,
         changeType: 'group',
         description: `Go ${majorVersion.version} series`,
-        summaryJa: `Go ${majorVersion.version} シリーズ`,
+        summary: `Go ${majorVersion.version} シリーズ`,
         releaseDate: majorVersion.release_date,
       },
       style: {
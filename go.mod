@@ -5,9 +5,8 @@ go 1.23.4
 require (
 	github.com/PuerkitoBio/goquery v1.10.3
 	github.com/mattn/go-sqlite3 v1.14.32
+	golang.org/x/net v0.39.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
-require (
-	github.com/andybalholm/cascadia v1.3.3 // indirect
-	golang.org/x/net v0.39.0 // indirect
-)
+require github.com/andybalholm/cascadia v1.3.3 // indirect
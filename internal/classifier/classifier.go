@@ -0,0 +1,169 @@
+// Package classifier は変更の説明文から変更種別を判定する
+// 判定は優先度付きの正規表現の規則（rules.json）で行い、スクレイパー・インポーターで共通に使う
+package classifier
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"sort"
+)
+
+// 変更種別
+const (
+	Added          = "Added"
+	Modified       = "Modified"
+	BehaviorChange = "Behavior Change"
+	Performance    = "Performance"
+	Deprecated     = "Deprecated"
+	Removed        = "Removed"
+	BugFix         = "Bug Fix"
+	SecurityFix    = "Security Fix"
+	Compatibility  = "Compatibility"
+	Hardening      = "Hardening"
+	Documentation  = "Documentation"
+)
+
+// Types は変更種別の一覧
+var Types = []string{
+	Added, Modified, BehaviorChange, Performance, Deprecated, Removed,
+	BugFix, SecurityFix, Compatibility, Hardening, Documentation,
+}
+
+const (
+	// fallbackConfidence はどの規則にも一致しなかった場合（Modified とみなす）の確信度
+	fallbackConfidence = 0.3
+	// ambiguityPenalty は別の種別の規則にも一致した場合に確信度に掛ける係数
+	ambiguityPenalty = 0.75
+)
+
+// Rule は変更種別を判定する規則
+type Rule struct {
+	Name       string  `json:"name"`
+	Type       string  `json:"type"`
+	Pattern    string  `json:"pattern"`
+	Priority   int     `json:"priority"`   // 複数の規則に一致した場合は大きいものを採用する
+	Confidence float64 `json:"confidence"` // 一致した場合の確信度（0〜1）
+
+	re *regexp.Regexp
+}
+
+// Result は判定結果
+type Result struct {
+	Type       string
+	Confidence float64
+	Rule       string // 採用した規則の名前（一致しなかった場合は空）
+}
+
+// Classifier は規則を優先度順に適用して変更種別を判定する
+type Classifier struct {
+	rules []Rule
+}
+
+//go:embed rules.json
+var defaultRules []byte
+
+var defaultClassifier *Classifier
+
+func init() {
+	rules, err := ParseRules(defaultRules)
+	if err != nil {
+		panic(fmt.Sprintf("組み込みの判定規則を読み込めませんでした: %v", err))
+	}
+	defaultClassifier = New(rules)
+}
+
+// Default は組み込みの規則による Classifier を返す
+func Default() *Classifier {
+	return defaultClassifier
+}
+
+// ParseRules は規則の JSON を読み込み、種別と正規表現を検証する
+func ParseRules(data []byte) ([]Rule, error) {
+	var rules []Rule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse classifier rules: %w", err)
+	}
+
+	for i := range rules {
+		r := &rules[i]
+		if r.Name == "" {
+			return nil, fmt.Errorf("classifier rule #%d has no name", i)
+		}
+		if !IsType(r.Type) {
+			return nil, fmt.Errorf("unknown change type %q in rule %s", r.Type, r.Name)
+		}
+		if r.Confidence <= 0 || r.Confidence > 1 {
+			return nil, fmt.Errorf("confidence of rule %s must be in (0, 1]: %v", r.Name, r.Confidence)
+		}
+		re, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern in rule %s: %w", r.Name, err)
+		}
+		r.re = re
+	}
+	return rules, nil
+}
+
+// Load はファイルから規則を読み込んだ Classifier を返す（組み込みの規則を置き換える）
+func Load(path string) (*Classifier, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read classifier rules: %w", err)
+	}
+	rules, err := ParseRules(data)
+	if err != nil {
+		return nil, err
+	}
+	return New(rules), nil
+}
+
+// New は規則から Classifier を作成する（優先度が同じ規則は記述順に適用する）
+func New(rules []Rule) *Classifier {
+	sorted := append([]Rule(nil), rules...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Priority > sorted[j].Priority
+	})
+	return &Classifier{rules: sorted}
+}
+
+// Classify は説明文の変更種別を判定する
+// 最も優先度の高い規則の種別を採用し、別の種別の規則にも一致した場合は確信度を下げる
+func (c *Classifier) Classify(text string) Result {
+	var result Result
+	ambiguous := false
+	for _, r := range c.rules {
+		if !r.re.MatchString(text) {
+			continue
+		}
+		if result.Rule == "" {
+			result = Result{Type: r.Type, Confidence: r.Confidence, Rule: r.Name}
+			continue
+		}
+		if r.Type != result.Type {
+			ambiguous = true
+			break
+		}
+	}
+
+	if result.Rule == "" {
+		return Result{Type: Modified, Confidence: fallbackConfidence}
+	}
+	if ambiguous {
+		result.Confidence = math.Round(result.Confidence*ambiguityPenalty*100) / 100
+	}
+	return result
+}
+
+// IsType は s が変更種別の一覧にあるか判定する
+func IsType(s string) bool {
+	for _, t := range Types {
+		if t == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package classifier
+
+import "testing"
+
+func TestClassify(t *testing.T) {
+	for text, want := range map[string]string{
+		"The Foo function is deprecated and will be removed in a future release.": Deprecated,
+		"The Foo function has been removed.":                                      Removed,
+		"The new function returns a fixed-size buffer.":                           Added,
+		"The new Rand type uses a fixed seed.":                                    Added,
+		"This release fixes a crash when reading empty archives.":                 BugFix,
+		"Go 1.21.1 fixed a panic in the decoder.":                                 BugFix,
+		"The Reader type now returns io.ErrUnexpectedEOF.":                        BehaviorChange,
+		// minor_revision_updates の JSON の説明文（以前の判定関数が書き出しの "Security fix:" / "Fix:" などで判定していたもの）
+		"Security fix: stack exhaustion in Decoder.Decode (CVE-2024-34156).":                       SecurityFix,
+		"Security-related fix: inconsistent handling of O_CREATE|O_EXCL across Unix and Windows.":  SecurityFix,
+		`Security-related fix: LookPath mis-expands "", ".", ".." in certain PATH configurations.`: SecurityFix,
+		"Security hardening: reject bare LF in chunked transfer-encoding.":                         Hardening,
+		"Bug fix: resource leak on exec failure.":                                                  BugFix,
+		"Fix: os.DevNull could not be opened with O_TRUNC on Windows.":                             BugFix,
+		"Test fix: TestNilDeref flaky failure on windows-386.":                                     BugFix,
+		"Test fixes / stability improvements.":                                                     BugFix,
+		"Compatibility: set default NextProtos to preserve HTTP/2/HTTP/1.1 negotiation behavior.":  Compatibility,
+	} {
+		if got := Default().Classify(text).Type; got != want {
+			t.Errorf("Classify(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package classifier
+
+import "strings"
+
+// legacySummaries は以前の変更種別（"Test Fix"・"Security Enhancement"）に付けていた要約
+var legacySummaries = map[string]string{
+	"Test Fix":             "テストの修正・安定化が行われました",
+	"Security Enhancement": "セキュリティ強化が行われました",
+}
+
+// IsGeneratedSummary は summary が説明文と変更種別から自動生成した要約か判定する
+// 手作業で書き換えた要約は再分類で上書きしない
+func IsGeneratedSummary(description, changeType, summary string) bool {
+	if summary == "" || summary == SummaryJa(description, changeType) {
+		return true
+	}
+	return legacySummaries[changeType] == summary
+}
+
+// SummaryJa は英語の変更内容を変更種別に応じて日本語で要約する
+func SummaryJa(description, changeType string) string {
+	if description == "" {
+		return ""
+	}
+
+	// 基本的なパターンマッチングによる要約
+	description = strings.ToLower(description)
+
+	switch changeType {
+	case Added:
+		if strings.Contains(description, "method") {
+			if strings.Contains(description, "new method") || strings.Contains(description, "added method") {
+				return "新しいメソッドが追加されました"
+			}
+		}
+		if strings.Contains(description, "function") {
+			return "新しい関数が追加されました"
+		}
+		if strings.Contains(description, "type") {
+			return "新しい型が追加されました"
+		}
+		if strings.Contains(description, "field") {
+			return "新しいフィールドが追加されました"
+		}
+		if strings.Contains(description, "constant") || strings.Contains(description, "const") {
+			return "新しい定数が追加されました"
+		}
+		if strings.Contains(description, "variable") || strings.Contains(description, "var") {
+			return "新しい変数が追加されました"
+		}
+		return "新機能が追加されました"
+
+	case Modified:
+		if strings.Contains(description, "error") {
+			return "エラー処理が改善されました"
+		}
+		if strings.Contains(description, "support") {
+			return "サポートが拡張されました"
+		}
+		return "機能が改善されました"
+
+	case BehaviorChange:
+		if strings.Contains(description, "godebug") {
+			return "動作が変更されました（GODEBUG で以前の動作に戻せます）"
+		}
+		return "動作が変更されました"
+
+	case Performance:
+		return "パフォーマンスが改善されました"
+
+	case Deprecated:
+		if strings.Contains(description, "method") {
+			return "メソッドが非推奨になりました"
+		}
+		if strings.Contains(description, "function") {
+			return "関数が非推奨になりました"
+		}
+		return "非推奨となりました"
+
+	case Removed:
+		if strings.Contains(description, "method") {
+			return "メソッドが削除されました"
+		}
+		if strings.Contains(description, "function") {
+			return "関数が削除されました"
+		}
+		return "機能が削除されました"
+
+	case BugFix:
+		if strings.Contains(description, "test") || strings.Contains(description, "stability") {
+			return "テストの修正・安定化が行われました"
+		}
+		return "バグが修正されました"
+
+	case SecurityFix:
+		return "セキュリティ修正が行われました"
+
+	case Compatibility:
+		return "互換性の改善が行われました"
+
+	case Hardening:
+		return "セキュリティ強化が行われました"
+
+	case Documentation:
+		return "ドキュメントが更新されました"
+
+	default:
+		return "変更が行われました"
+	}
+}
@@ -0,0 +1,190 @@
+package database
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"go-ver-trace/internal/goversion"
+)
+
+// ChangeTypeBase はマイナーリリース（1.23.1 など）にだけ変更があるパッケージを、系列の最初のリリース（1.23）に置くための変更種別
+// 保存した変更ではなく、リリースと変更から導出する（可視化で系列のリリースからマイナーリリースへの流れを描くため）
+const ChangeTypeBase = "Base"
+
+// BaseEntry は系列の最初のリリースに変更がなく、マイナーリリースにだけ変更があるパッケージ
+type BaseEntry struct {
+	ReleaseID    int    `json:"release_id"`    // 系列の最初のリリース（1.23 など）
+	Version      string `json:"version"`       // 系列の最初のリリースのバージョン
+	Package      string `json:"package"`       // パッケージ
+	Area         string `json:"area"`          // マイナーリリースの変更の領域
+	FirstVersion string `json:"first_version"` // 変更が最初にあったマイナーリリース（1.23.1 など）
+}
+
+// migrateBaseEntries はベースエントリのテーブルを作成し、以前 -create-base で package_changes に保存した
+// ベースエントリの行を削除してから、ベースエントリを導出し直す
+func (d *Database) migrateBaseEntries() error {
+	_, err := d.db.Exec(`CREATE TABLE IF NOT EXISTS base_entries (
+			release_id INTEGER NOT NULL,
+			package TEXT NOT NULL,
+			area TEXT NOT NULL,
+			first_version TEXT NOT NULL,
+			PRIMARY KEY (release_id, package),
+			FOREIGN KEY (release_id) REFERENCES releases(id)
+		)`)
+	if err != nil {
+		return fmt.Errorf("failed to migrate base entries: %w", err)
+	}
+
+	var legacy int
+	if err := d.db.QueryRow(`SELECT COUNT(*) FROM package_changes WHERE change_type = ?`, ChangeTypeBase).Scan(&legacy); err != nil {
+		return fmt.Errorf("failed to count legacy base entries: %w", err)
+	}
+	if legacy > 0 {
+		if err := d.deleteLegacyBaseEntries(); err != nil {
+			return err
+		}
+		log.Printf("package_changes のベースエントリ %d 件を削除しました（ベースエントリはリリースから導出します）", legacy)
+	}
+	_, err = d.RefreshBaseEntries()
+	return err
+}
+
+// deleteLegacyBaseEntries は package_changes に保存したベースエントリの行と、それに紐付く行を削除する
+func (d *Database) deleteLegacyBaseEntries() error {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	baseChanges := `SELECT id FROM package_changes WHERE change_type = '` + ChangeTypeBase + `'`
+	for _, table := range []string{"change_links", "change_platforms", "change_translations", "package_change_vulnerabilities"} {
+		if _, err := tx.Exec(`DELETE FROM ` + table + ` WHERE change_id IN (` + baseChanges + `)`); err != nil {
+			return fmt.Errorf("failed to delete %s of legacy base entries: %w", table, err)
+		}
+	}
+	if _, err := tx.Exec(`DELETE FROM package_changes WHERE change_type = ?`, ChangeTypeBase); err != nil {
+		return fmt.Errorf("failed to delete legacy base entries: %w", err)
+	}
+	return tx.Commit()
+}
+
+// RefreshBaseEntries はリリースと変更からベースエントリを導出し直し、その件数を返す
+// 正式リリースのマイナーリリース（1.23.1 など）に変更があり、系列の最初のリリース（1.23）に変更がないパッケージが対象
+// 系列の最初のリリースがない系列は対象外
+func (d *Database) RefreshBaseEntries() (int, error) {
+	releases, err := d.GetAllReleases()
+	if err != nil {
+		return 0, err
+	}
+	rows, err := d.db.Query(`SELECT release_id, package, MIN(COALESCE(area, '`+DefaultArea+`')) FROM package_changes
+		WHERE change_type != ? GROUP BY release_id, package ORDER BY release_id, package`, ChangeTypeBase)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query packages of releases: %w", err)
+	}
+	type releasePackage struct{ name, area string }
+	packages := make(map[int][]releasePackage)
+	for rows.Next() {
+		var releaseID int
+		var pkg releasePackage
+		if err := rows.Scan(&releaseID, &pkg.name, &pkg.area); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan package of release: %w", err)
+		}
+		packages[releaseID] = append(packages[releaseID], pkg)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	// 系列の最初のリリースと、系列のマイナーリリースを古い順に集める
+	branches := make(map[string]Release)
+	points := make(map[string][]Release)
+	for _, r := range releases {
+		v, err := goversion.Parse(r.Version)
+		if err != nil || v.Pre != "" {
+			continue
+		}
+		if v.Patch == 0 {
+			branches[v.Branch()] = r
+		} else {
+			points[v.Branch()] = append(points[v.Branch()], r)
+		}
+	}
+
+	var entries []BaseEntry
+	for branch, minors := range points {
+		base, ok := branches[branch]
+		if !ok {
+			continue
+		}
+		sort.Slice(minors, func(i, j int) bool { return goversion.Compare(minors[i].Version, minors[j].Version) < 0 })
+
+		seen := make(map[string]bool)
+		for _, pkg := range packages[base.ID] {
+			seen[pkg.name] = true
+		}
+		for _, minor := range minors {
+			for _, pkg := range packages[minor.ID] {
+				if !seen[pkg.name] {
+					seen[pkg.name] = true
+					entries = append(entries, BaseEntry{ReleaseID: base.ID, Version: base.Version, Package: pkg.name, Area: pkg.area, FirstVersion: minor.Version})
+				}
+			}
+		}
+	}
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	if _, err := tx.Exec(`DELETE FROM base_entries`); err != nil {
+		return 0, fmt.Errorf("failed to clear base entries: %w", err)
+	}
+	for _, e := range entries {
+		_, err := tx.Exec(`INSERT INTO base_entries (release_id, package, area, first_version) VALUES (?, ?, ?, ?)`, e.ReleaseID, e.Package, e.Area, e.FirstVersion)
+		if err != nil {
+			return 0, fmt.Errorf("failed to save base entry %s (Go %s): %w", e.Package, e.Version, err)
+		}
+	}
+	return len(entries), tx.Commit()
+}
+
+// GetBaseEntries は packageName のベースエントリを返す（空の場合はすべてのパッケージ）
+func (d *Database) GetBaseEntries(packageName string) ([]BaseEntry, error) {
+	rows, err := d.db.Query(`SELECT b.release_id, r.version, b.package, b.area, b.first_version FROM base_entries b
+		JOIN releases r ON r.id = b.release_id
+		WHERE ? = '' OR b.package = ? ORDER BY r.release_date, b.package`, packageName, packageName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query base entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []BaseEntry{}
+	for rows.Next() {
+		var e BaseEntry
+		if err := rows.Scan(&e.ReleaseID, &e.Version, &e.Package, &e.Area, &e.FirstVersion); err != nil {
+			return nil, fmt.Errorf("failed to scan base entry: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+// Change はベースエントリを可視化用の変更として返す（ID は 0）
+func (e BaseEntry) Change() PackageChange {
+	description := fmt.Sprintf("Base package entry for %s (introduced in Go %s)", e.Package, e.FirstVersion)
+	return PackageChange{
+		ReleaseID:   e.ReleaseID,
+		Package:     e.Package,
+		ChangeType:  ChangeTypeBase,
+		Description: description,
+		Excerpt:     description,
+		Summary:     fmt.Sprintf("ベースパッケージエントリ（Go %s で導入）", e.FirstVersion),
+		SummaryLang: DefaultLanguage,
+		Area:        e.Area,
+	}
+}
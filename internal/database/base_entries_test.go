@@ -0,0 +1,106 @@
+package database
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// saveTestChanges はリリースと、そのリリースのパッケージの変更を保存する
+func saveTestChanges(t *testing.T, d *Database, version string, packages ...string) int {
+	t.Helper()
+	releaseID, err := d.SaveRelease(0, version, time.Date(2024, 8, 13, 0, 0, 0, 0, time.UTC), "https://go.dev/doc/devel/release#go"+version)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, pkg := range packages {
+		_, err := d.InsertPackageChange(PackageChange{ReleaseID: releaseID, Package: pkg, ChangeType: "Modified", Description: "Go " + version + " fixes " + pkg})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return releaseID
+}
+
+// baseEntryVersions はパッケージごとの「系列の最初のリリース <- 変更が最初にあったマイナーリリース」を返す
+func baseEntryVersions(t *testing.T, d *Database) map[string]string {
+	t.Helper()
+	entries, err := d.GetBaseEntries("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]string)
+	for _, e := range entries {
+		got[e.Package] = e.Version + " <- " + e.FirstVersion
+	}
+	return got
+}
+
+// TestRefreshBaseEntries はマイナーリリースにだけ変更があるパッケージのベースエントリを系列の最初のリリースに導出することを確認する
+func TestRefreshBaseEntries(t *testing.T) {
+	d := newTestDatabase(t)
+	base := saveTestChanges(t, d, "1.23", "net/http")
+	saveTestChanges(t, d, "1.23.1", "net/http", "os")
+	saveTestChanges(t, d, "1.23.2", "os", "crypto/tls")
+	// 系列の最初のリリースがない系列と、プレリリースは対象外
+	saveTestChanges(t, d, "1.24.1", "go/types")
+	saveTestChanges(t, d, "1.25rc1", "encoding/json")
+
+	n, err := d.RefreshBaseEntries()
+	if err != nil {
+		t.Fatalf("RefreshBaseEntries: %v", err)
+	}
+	want := map[string]string{
+		"os":         "1.23 <- 1.23.1",
+		"crypto/tls": "1.23 <- 1.23.2",
+	}
+	if got := baseEntryVersions(t, d); n != len(want) || !reflect.DeepEqual(got, want) {
+		t.Errorf("base entries = %v (%d), want %v", got, n, want)
+	}
+
+	// 系列の最初のリリースに変更が増えたパッケージはベースエントリでなくなる
+	if _, err := d.InsertPackageChange(PackageChange{ReleaseID: base, Package: "os", ChangeType: "Modified", Description: "Go 1.23 changes os"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.RefreshBaseEntries(); err != nil {
+		t.Fatal(err)
+	}
+	want = map[string]string{"crypto/tls": "1.23 <- 1.23.2"}
+	if got := baseEntryVersions(t, d); !reflect.DeepEqual(got, want) {
+		t.Errorf("base entries after adding os to 1.23 = %v, want %v", got, want)
+	}
+}
+
+// TestMigrateLegacyBaseEntries は以前 -create-base で package_changes に保存したベースエントリを削除し、導出し直すことを確認する
+func TestMigrateLegacyBaseEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	d, err := New(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := saveTestChanges(t, d, "1.22")
+	saveTestChanges(t, d, "1.22.1", "net/http")
+	if _, err := d.InsertPackageChange(PackageChange{ReleaseID: base, Package: "net/http", ChangeType: ChangeTypeBase, Description: "Base package entry for net/http"}); err != nil {
+		t.Fatal(err)
+	}
+	d.Close()
+
+	d, err = New(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+
+	var legacy int
+	if err := d.db.QueryRow(`SELECT COUNT(*) FROM package_changes WHERE change_type = ?`, ChangeTypeBase).Scan(&legacy); err != nil {
+		t.Fatal(err)
+	}
+	if legacy != 0 {
+		t.Errorf("%d legacy base entries remain in package_changes, want 0", legacy)
+	}
+	want := map[string]string{"net/http": "1.22 <- 1.22.1"}
+	if got := baseEntryVersions(t, d); !reflect.DeepEqual(got, want) {
+		t.Errorf("base entries = %v, want %v", got, want)
+	}
+}
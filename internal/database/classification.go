@@ -0,0 +1,39 @@
+package database
+
+import "fmt"
+
+// ChangeClassification は変更の再分類の結果
+type ChangeClassification struct {
+	ID         int
+	ChangeType string
+	Confidence float64
+	SummaryJa  string
+}
+
+// migrateChangeTypeConfidence は変更種別の判定の確信度のカラムを追加する
+func (d *Database) migrateChangeTypeConfidence() error {
+	if err := d.addColumnIfNotExists("package_changes", "change_type_confidence", "REAL"); err != nil {
+		return fmt.Errorf("failed to migrate change_type_confidence column: %w", err)
+	}
+	return nil
+}
+
+// UpdateChangeClassifications は変更種別・確信度・日本語要約をまとめて更新する（途中で失敗した場合は何も更新しない）
+func (d *Database) UpdateChangeClassifications(updates []ChangeClassification) error {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return err
+	}
+	for _, u := range updates {
+		_, err := tx.Exec(`UPDATE package_changes SET change_type = ?, change_type_confidence = NULLIF(?, 0) WHERE id = ?`,
+			u.ChangeType, u.Confidence, u.ID)
+		if err == nil {
+			err = saveTranslation(tx, ChangeTranslation{ChangeID: u.ID, Lang: DefaultLanguage, Summary: u.SummaryJa})
+		}
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to reclassify change %d: %w", u.ID, err)
+		}
+	}
+	return tx.Commit()
+}
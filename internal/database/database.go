@@ -3,9 +3,15 @@ package database
 import (
 	"database/sql"
 	"fmt"
+	"html"
+	"sort"
+	"strings"
 	"time"
 
 	_ "github.com/mattn/go-sqlite3"
+
+	"go-ver-trace/internal/goversion"
+	"go-ver-trace/internal/platform"
 )
 
 type Database struct {
@@ -13,22 +19,114 @@ type Database struct {
 }
 
 type Release struct {
-	ID          int       `json:"id"`
-	Version     string    `json:"version"`
-	ReleaseDate time.Time `json:"release_date"`
-	URL         string    `json:"url"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID             int       `json:"id"`
+	Version        string    `json:"version"`
+	ReleaseDate    time.Time `json:"release_date"`
+	URL            string    `json:"url"`
+	Prerelease     bool      `json:"prerelease"` // beta / rc
+	IngestionRunID int       `json:"ingestion_run_id,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// Vulnerability は Go 脆弱性データベース（OSV 形式）のエントリ
+type Vulnerability struct {
+	ID       string   `json:"id"`
+	Aliases  []string `json:"aliases"`
+	Summary  string   `json:"summary"`
+	Details  string   `json:"details"`
+	Modified string   `json:"modified"`
 }
 
 type PackageChange struct {
-	ID          int       `json:"id"`
-	ReleaseID   int       `json:"release_id"`
-	Package     string    `json:"package"`
-	ChangeType  string    `json:"change_type"`
-	Description string    `json:"description"`
-	SummaryJa   string    `json:"summary_ja"`
-	SourceURL   string    `json:"source_url"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID                   int                `json:"id"`
+	ReleaseID            int                `json:"release_id"`
+	Package              string             `json:"package"`
+	ChangeType           string             `json:"change_type"`
+	ChangeTypeConfidence float64            `json:"change_type_confidence,omitempty"` // 変更種別の判定の確信度（0〜1）
+	Description          string             `json:"description"`
+	DescriptionHTML      string             `json:"description_html,omitempty"`
+	Excerpt              string             `json:"excerpt"`
+	Summary              string             `json:"summary"`                  // SummaryLang の要約（要約がない場合は英語の説明文の抜粋）
+	SummaryLang          string             `json:"summary_lang,omitempty"`   // BCP-47 言語タグ
+	SummaryStatus        string             `json:"summary_status,omitempty"` // 要約の確認状態（英語の説明文の抜粋の場合は空）
+	SourceURL            string             `json:"source_url"`
+	Links                []ChangeLink       `json:"links,omitempty"`
+	Area                 string             `json:"area"`
+	Subheading           string             `json:"subheading"`
+	Experiment           string             `json:"experiment,omitempty"`
+	ExperimentStatus     string             `json:"experiment_status,omitempty"`
+	Platforms            []platform.Platform `json:"platforms,omitempty"`
+	IngestionRunID       int                `json:"ingestion_run_id,omitempty"`
+	GAChangeID           int                `json:"ga_change_id,omitempty"` // プレリリースの変更に対応する正式リリースの変更
+	OverrideID           int                `json:"override_id,omitempty"`  // 適用した上書き設定（読み出し時のみ）
+	CreatedAt            time.Time          `json:"created_at"`
+}
+
+// ChangeLink は変更に紐づく参照リンク（kind: doc / issue / cl / proposal / external）
+type ChangeLink struct {
+	URL  string `json:"url"`
+	Text string `json:"text"`
+	Kind string `json:"kind"`
+}
+
+// 説明文の表現形式（API の format パラメータ）
+const (
+	DescriptionFormatText    = "text"
+	DescriptionFormatHTML    = "html"
+	DescriptionFormatExcerpt = "excerpt"
+)
+
+// IsValidDescriptionFormat は指定された表現形式がサポートされているか判定する
+func IsValidDescriptionFormat(format string) bool {
+	switch format {
+	case DescriptionFormatText, DescriptionFormatHTML, DescriptionFormatExcerpt:
+		return true
+	}
+	return false
+}
+
+// DescriptionAs は指定された表現形式の説明文を返す
+// HTML を持たない行（JSON インポート等）はテキストをエスケープして返す
+func (c PackageChange) DescriptionAs(format string) string {
+	switch format {
+	case DescriptionFormatHTML:
+		if c.DescriptionHTML != "" {
+			return c.DescriptionHTML
+		}
+		return "<p>" + html.EscapeString(c.Description) + "</p>"
+	case DescriptionFormatExcerpt:
+		if c.Excerpt != "" {
+			return c.Excerpt
+		}
+		return c.Description
+	default:
+		return c.Description
+	}
+}
+
+// DefaultArea は領域が未設定の行（既存データ・JSON インポート）の領域
+const DefaultArea = "stdlib"
+
+// VisualizationOptions は可視化データの取得条件
+type VisualizationOptions struct {
+	DescriptionFormat string             // text / html / excerpt（空の場合は text）
+	Areas             []string           // 対象領域（空の場合はすべて）
+	Platforms         []platform.Platform // 対象プラットフォーム（空の場合はすべて）
+	IncludeUpcoming   bool               // 正式リリース前のプレリリースの変更も含める
+	Languages         []string           // 要約の言語の希望順（空の場合は既定の言語）
+}
+
+// IncludesArea は変更の領域が取得条件に含まれるか判定する
+func (o VisualizationOptions) IncludesArea(area string) bool {
+	if len(o.Areas) == 0 {
+		return true
+	}
+	for _, a := range o.Areas {
+		if a == area {
+			return true
+		}
+	}
+	return false
 }
 
 func New(dbPath string) (*Database, error) {
@@ -65,11 +163,71 @@ func (d *Database) createTables() error {
 			package TEXT NOT NULL,
 			change_type TEXT NOT NULL,
 			description TEXT,
-			summary_ja TEXT,
+			summary_ja TEXT, -- 旧形式（要約は change_translations に保存する）
 			source_url TEXT,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			FOREIGN KEY (release_id) REFERENCES releases (id) ON DELETE CASCADE
 		)`,
+		`CREATE TABLE IF NOT EXISTS vulnerabilities (
+			id TEXT PRIMARY KEY,
+			aliases TEXT,
+			summary TEXT,
+			details TEXT,
+			modified TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS package_change_vulnerabilities (
+			change_id INTEGER NOT NULL,
+			vuln_id TEXT NOT NULL,
+			PRIMARY KEY (change_id, vuln_id),
+			FOREIGN KEY (change_id) REFERENCES package_changes (id) ON DELETE CASCADE,
+			FOREIGN KEY (vuln_id) REFERENCES vulnerabilities (id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS change_links (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			change_id INTEGER NOT NULL,
+			url TEXT NOT NULL,
+			text TEXT,
+			kind TEXT NOT NULL,
+			FOREIGN KEY (change_id) REFERENCES package_changes (id) ON DELETE CASCADE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_change_links_change_id ON change_links (change_id)`,
+		`CREATE TABLE IF NOT EXISTS change_platforms (
+			change_id INTEGER NOT NULL,
+			goos TEXT NOT NULL DEFAULT '',
+			goarch TEXT NOT NULL DEFAULT '',
+			PRIMARY KEY (change_id, goos, goarch),
+			FOREIGN KEY (change_id) REFERENCES package_changes (id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS godebug_events (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL,
+			version TEXT NOT NULL,
+			kind TEXT NOT NULL,
+			value TEXT,
+			package TEXT,
+			description TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (name, version)
+		)`,
+		`CREATE TABLE IF NOT EXISTS ingestion_runs (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			started_at DATETIME NOT NULL,
+			finished_at DATETIME,
+			status TEXT NOT NULL,
+			release_count INTEGER NOT NULL DEFAULT 0,
+			change_count INTEGER NOT NULL DEFAULT 0
+		)`,
+		`CREATE TABLE IF NOT EXISTS ingestion_diagnostics (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			run_id INTEGER NOT NULL,
+			version TEXT NOT NULL,
+			kind TEXT NOT NULL,
+			subject TEXT NOT NULL,
+			detail TEXT,
+			FOREIGN KEY (run_id) REFERENCES ingestion_runs (id) ON DELETE CASCADE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_ingestion_diagnostics_run_id ON ingestion_diagnostics (run_id)`,
 		`CREATE INDEX IF NOT EXISTS idx_package_changes_package ON package_changes (package)`,
 		`CREATE INDEX IF NOT EXISTS idx_package_changes_change_type ON package_changes (change_type)`,
 		`CREATE INDEX IF NOT EXISTS idx_releases_version ON releases (version)`,
@@ -91,6 +249,92 @@ func (d *Database) createTables() error {
 		return fmt.Errorf("failed to migrate source_url column: %w", err)
 	}
 
+	// 説明文全体の HTML と抜粋を保持するカラムを追加するマイグレーション
+	if err := d.addColumnIfNotExists("package_changes", "description_html", "TEXT"); err != nil {
+		return fmt.Errorf("failed to migrate description_html column: %w", err)
+	}
+	if err := d.addColumnIfNotExists("package_changes", "excerpt", "TEXT"); err != nil {
+		return fmt.Errorf("failed to migrate excerpt column: %w", err)
+	}
+
+	// 領域（language, tools, runtime など）と見出しのカラムを追加するマイグレーション
+	if err := d.addColumnIfNotExists("package_changes", "area", "TEXT NOT NULL DEFAULT '"+DefaultArea+"'"); err != nil {
+		return fmt.Errorf("failed to migrate area column: %w", err)
+	}
+	if err := d.addColumnIfNotExists("package_changes", "subheading", "TEXT"); err != nil {
+		return fmt.Errorf("failed to migrate subheading column: %w", err)
+	}
+	if _, err := d.db.Exec(`CREATE INDEX IF NOT EXISTS idx_package_changes_area ON package_changes (area)`); err != nil {
+		return fmt.Errorf("failed to create area index: %w", err)
+	}
+
+	// 実験的機能（GOEXPERIMENT）の名前と状態のカラムを追加するマイグレーション
+	if err := d.addColumnIfNotExists("package_changes", "experiment", "TEXT"); err != nil {
+		return fmt.Errorf("failed to migrate experiment column: %w", err)
+	}
+	if err := d.addColumnIfNotExists("package_changes", "experiment_status", "TEXT"); err != nil {
+		return fmt.Errorf("failed to migrate experiment_status column: %w", err)
+	}
+
+	// 行ごとの取得元（実行記録）を保持するカラムを追加するマイグレーション
+	if err := d.migrateIngestionProvenance(); err != nil {
+		return err
+	}
+
+	// プレリリースのフラグと正式リリースとの対応を保持するカラムを追加するマイグレーション
+	if err := d.migratePrereleases(); err != nil {
+		return err
+	}
+
+	// 変更種別の判定の確信度を保持するカラムを追加するマイグレーション
+	if err := d.migrateChangeTypeConfidence(); err != nil {
+		return err
+	}
+
+	// 手作業による補正（上書き設定）と変更履歴のテーブルを作成するマイグレーション
+	if err := d.migrateOverrides(); err != nil {
+		return err
+	}
+
+	// 説明文ごとの要約を保存するテーブルを作成するマイグレーション
+	if err := d.migrateSummaryCache(); err != nil {
+		return err
+	}
+
+	// 言語ごとの要約のテーブルを作成し、summary_ja の要約を移すマイグレーション
+	if err := d.migrateChangeTranslations(); err != nil {
+		return err
+	}
+
+	// 用語集のテーブルを作成するマイグレーション
+	if err := d.migrateGlossary(); err != nil {
+		return err
+	}
+	if err := d.migrateBaseEntries(); err != nil {
+		return err
+	}
+
+	// 実行が書き換えた既存の行を取り消しのために保存するテーブルを作成するマイグレーション
+	if err := d.migrateIngestionSnapshots(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// addColumnIfNotExists は指定テーブルにカラムが存在しない場合のみ追加する
+func (d *Database) addColumnIfNotExists(table, column, definition string) error {
+	var count int
+	err := d.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?`, table, column).Scan(&count)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		_, err = d.db.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s %s`, table, column, definition))
+		return err
+	}
+
 	return nil
 }
 
@@ -138,9 +382,13 @@ func (d *Database) addSourceURLColumn() error {
 	return nil
 }
 
-func (d *Database) SaveRelease(version string, releaseDate time.Time, url string) (int, error) {
-	query := `INSERT OR REPLACE INTO releases (version, release_date, url) VALUES (?, ?, ?)`
-	result, err := d.db.Exec(query, version, releaseDate, url)
+// SaveRelease はリリースを実行記録 runID（0 は記録なし）に紐付けて保存する
+func (d *Database) SaveRelease(runID int, version string, releaseDate time.Time, url string) (int, error) {
+	if err := d.snapshotRelease(d.db, runID, version); err != nil {
+		return 0, err
+	}
+	query := `INSERT OR REPLACE INTO releases (version, release_date, url, prerelease, ingestion_run_id) VALUES (?, ?, ?, ?, NULLIF(?, 0))`
+	result, err := d.db.Exec(query, version, releaseDate, url, goversion.IsPrerelease(version), runID)
 	if err != nil {
 		return 0, fmt.Errorf("failed to save release: %w", err)
 	}
@@ -153,35 +401,137 @@ func (d *Database) SaveRelease(version string, releaseDate time.Time, url string
 	return int(id), nil
 }
 
-func (d *Database) SavePackageChange(releaseID int, packageName, changeType, description string) error {
-	query := `INSERT INTO package_changes (release_id, package, change_type, description) VALUES (?, ?, ?, ?)`
-	_, err := d.db.Exec(query, releaseID, packageName, changeType, description)
+// InsertPackageChange は変更を実行記録 c.IngestionRunID（0 は記録なし）に紐付けて保存し、採番された ID を返す
+func (d *Database) InsertPackageChange(c PackageChange) (int, error) {
+	return d.insertPackageChange(d.db, c)
+}
+
+// insertPackageChange は exec で変更と参照リンク・対象プラットフォーム・要約を保存し、採番された ID を返す
+func (d *Database) insertPackageChange(exec execer, c PackageChange) (int, error) {
+	area := c.Area
+	if area == "" {
+		area = DefaultArea
+	}
+
+	query := `INSERT INTO package_changes (release_id, package, change_type, change_type_confidence, description, description_html, excerpt, source_url, area, subheading, experiment, experiment_status, ingestion_run_id)
+			  VALUES (?, ?, ?, NULLIF(?, 0), ?, ?, ?, ?, ?, ?, ?, ?, NULLIF(?, 0))`
+	result, err := exec.Exec(query, c.ReleaseID, c.Package, c.ChangeType, c.ChangeTypeConfidence, c.Description, c.DescriptionHTML, c.Excerpt, c.SourceURL, area, c.Subheading, c.Experiment, c.ExperimentStatus, c.IngestionRunID)
 	if err != nil {
-		return fmt.Errorf("failed to save package change: %w", err)
+		return 0, fmt.Errorf("failed to insert package change: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	if err := saveChangeLinks(exec, int(id), c.Links); err != nil {
+		return 0, err
+	}
+
+	if err := saveChangePlatforms(exec, int(id), c.Platforms); err != nil {
+		return 0, err
+	}
+
+	// 要約は言語ごとに change_translations に保存する（言語の指定がない場合は既定の言語）
+	// 説明文が同じ変更に人が書いた要約があれば、取り込み時の要約より優先して引き継ぐ
+	if err := carryOverTranslations(exec, int(id), c.Description); err != nil {
+		return 0, err
+	}
+	lang := c.SummaryLang
+	if lang == "" {
+		lang = DefaultLanguage
+	}
+	if c.Summary != "" {
+		_, err := exec.Exec(`INSERT OR IGNORE INTO change_translations (change_id, lang, summary, updated_at) VALUES (?, ?, ?, ?)`,
+			id, lang, c.Summary, time.Now().UTC())
+		if err != nil {
+			return 0, fmt.Errorf("failed to save %s summary of change %d: %w", lang, id, err)
+		}
+	}
+
+	return int(id), nil
+}
+
+// saveChangeLinks は exec で変更に紐づく参照リンクを保存する
+func saveChangeLinks(exec execer, changeID int, links []ChangeLink) error {
+	for _, link := range links {
+		_, err := exec.Exec(`INSERT INTO change_links (change_id, url, text, kind) VALUES (?, ?, ?, ?)`,
+			changeID, link.URL, link.Text, link.Kind)
+		if err != nil {
+			return fmt.Errorf("failed to save link for change %d: %w", changeID, err)
+		}
 	}
 	return nil
 }
 
-func (d *Database) SavePackageChangeWithSummary(releaseID int, packageName, changeType, description, summaryJa string) error {
-	query := `INSERT INTO package_changes (release_id, package, change_type, description, summary_ja) VALUES (?, ?, ?, ?, ?)`
-	_, err := d.db.Exec(query, releaseID, packageName, changeType, description, summaryJa)
+// GetChangeLinks は変更 ID ごとの参照リンク一覧を返す
+func (d *Database) GetChangeLinks() (map[int][]ChangeLink, error) {
+	return d.queryChangeLinks("", nil)
+}
+
+// GetChangeLinksOf は指定した変更の参照リンク一覧を変更 ID ごとに返す
+func (d *Database) GetChangeLinksOf(changeIDs []int) (map[int][]ChangeLink, error) {
+	if len(changeIDs) == 0 {
+		return map[int][]ChangeLink{}, nil
+	}
+	where, args := changeIDCondition(changeIDs)
+	return d.queryChangeLinks(where, args)
+}
+
+func (d *Database) queryChangeLinks(where string, args []interface{}) (map[int][]ChangeLink, error) {
+	rows, err := d.db.Query(`SELECT change_id, url, COALESCE(text, ''), kind FROM change_links `+where+` ORDER BY id`, args...)
 	if err != nil {
-		return fmt.Errorf("failed to save package change with summary: %w", err)
+		return nil, fmt.Errorf("failed to query change links: %w", err)
 	}
-	return nil
+	defer rows.Close()
+
+	result := make(map[int][]ChangeLink)
+	for rows.Next() {
+		var changeID int
+		var link ChangeLink
+		if err := rows.Scan(&changeID, &link.URL, &link.Text, &link.Kind); err != nil {
+			return nil, fmt.Errorf("failed to scan change link: %w", err)
+		}
+		result[changeID] = append(result[changeID], link)
+	}
+
+	return result, rows.Err()
+}
+
+// changeIDCondition は change_id が指定した ID のいずれかに一致する WHERE 句とその引数を返す
+func changeIDCondition(changeIDs []int) (string, []interface{}) {
+	args := make([]interface{}, len(changeIDs))
+	for i, id := range changeIDs {
+		args[i] = id
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(changeIDs)), ",")
+	return `WHERE change_id IN (` + placeholders + `)`, args
 }
 
-func (d *Database) SavePackageChangeWithSourceURL(releaseID int, packageName, changeType, description, summaryJa, sourceURL string) error {
-	query := `INSERT INTO package_changes (release_id, package, change_type, description, summary_ja, source_url) VALUES (?, ?, ?, ?, ?, ?)`
-	_, err := d.db.Exec(query, releaseID, packageName, changeType, description, summaryJa, sourceURL)
+// GetChangeVulnerabilityIDs は変更 ID ごとの脆弱性 ID 一覧を返す
+func (d *Database) GetChangeVulnerabilityIDs() (map[int][]string, error) {
+	rows, err := d.db.Query(`SELECT change_id, vuln_id FROM package_change_vulnerabilities ORDER BY vuln_id`)
 	if err != nil {
-		return fmt.Errorf("failed to save package change with source URL: %w", err)
+		return nil, fmt.Errorf("failed to query change vulnerabilities: %w", err)
 	}
-	return nil
+	defer rows.Close()
+
+	result := make(map[int][]string)
+	for rows.Next() {
+		var changeID int
+		var vulnID string
+		if err := rows.Scan(&changeID, &vulnID); err != nil {
+			return nil, fmt.Errorf("failed to scan change vulnerability: %w", err)
+		}
+		result[changeID] = append(result[changeID], vulnID)
+	}
+
+	return result, nil
 }
 
 func (d *Database) GetAllReleases() ([]Release, error) {
-	query := `SELECT id, version, release_date, url, created_at FROM releases ORDER BY release_date`
+	query := `SELECT id, version, release_date, url, prerelease, COALESCE(ingestion_run_id, 0), created_at FROM releases ORDER BY release_date`
 	rows, err := d.db.Query(query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query releases: %w", err)
@@ -191,7 +541,7 @@ func (d *Database) GetAllReleases() ([]Release, error) {
 	var releases []Release
 	for rows.Next() {
 		var r Release
-		if err := rows.Scan(&r.ID, &r.Version, &r.ReleaseDate, &r.URL, &r.CreatedAt); err != nil {
+		if err := rows.Scan(&r.ID, &r.Version, &r.ReleaseDate, &r.URL, &r.Prerelease, &r.IngestionRunID, &r.CreatedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan release: %w", err)
 		}
 		releases = append(releases, r)
@@ -200,31 +550,47 @@ func (d *Database) GetAllReleases() ([]Release, error) {
 	return releases, nil
 }
 
-func (d *Database) GetPackageChanges(releaseID int) ([]PackageChange, error) {
-	query := `SELECT id, release_id, package, change_type, description, 
-			  COALESCE(summary_ja, '') as summary_ja, COALESCE(source_url, '') as source_url, created_at 
-			  FROM package_changes WHERE release_id = ? ORDER BY package`
-	rows, err := d.db.Query(query, releaseID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to query package changes: %w", err)
-	}
-	defer rows.Close()
-
+// package_changes の共通 SELECT 句（エイリアス pc 前提）
+const packageChangeColumns = `pc.id, pc.release_id, pc.package, pc.change_type,
+			  COALESCE(pc.change_type_confidence, 0) as change_type_confidence, COALESCE(pc.description, '') as description,
+			  COALESCE(pc.description_html, '') as description_html, COALESCE(pc.excerpt, '') as excerpt,
+			  COALESCE((SELECT t.summary FROM change_translations t WHERE t.change_id = pc.id AND t.lang = '` + DefaultLanguage + `'), '') as summary,
+			  COALESCE((SELECT t.status FROM change_translations t WHERE t.change_id = pc.id AND t.lang = '` + DefaultLanguage + `'), '') as summary_status,
+			  COALESCE(pc.source_url, '') as source_url,
+			  COALESCE(pc.area, '` + DefaultArea + `') as area, COALESCE(pc.subheading, '') as subheading,
+			  COALESCE(pc.experiment, '') as experiment, COALESCE(pc.experiment_status, '') as experiment_status,
+			  COALESCE(pc.ingestion_run_id, 0) as ingestion_run_id, COALESCE(pc.ga_change_id, 0) as ga_change_id, pc.created_at`
+
+// scanPackageChanges は packageChangeColumns で取得した行を読み込む
+func scanPackageChanges(rows *sql.Rows) ([]PackageChange, error) {
 	var changes []PackageChange
 	for rows.Next() {
 		var c PackageChange
-		if err := rows.Scan(&c.ID, &c.ReleaseID, &c.Package, &c.ChangeType, &c.Description, &c.SummaryJa, &c.SourceURL, &c.CreatedAt); err != nil {
+		if err := rows.Scan(&c.ID, &c.ReleaseID, &c.Package, &c.ChangeType, &c.ChangeTypeConfidence, &c.Description, &c.DescriptionHTML, &c.Excerpt, &c.Summary, &c.SummaryStatus, &c.SourceURL, &c.Area, &c.Subheading, &c.Experiment, &c.ExperimentStatus, &c.IngestionRunID, &c.GAChangeID, &c.CreatedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan package change: %w", err)
 		}
+		if c.Summary != "" {
+			c.SummaryLang = DefaultLanguage
+		}
 		changes = append(changes, c)
 	}
+	return changes, rows.Err()
+}
 
-	return changes, nil
+func (d *Database) GetPackageChanges(releaseID int) ([]PackageChange, error) {
+	query := `SELECT ` + packageChangeColumns + `
+			  FROM package_changes pc WHERE pc.release_id = ? ORDER BY pc.package`
+	rows, err := d.db.Query(query, releaseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query package changes: %w", err)
+	}
+	defer rows.Close()
+
+	return scanPackageChanges(rows)
 }
 
 func (d *Database) GetAllPackageChanges() ([]PackageChange, error) {
-	query := `SELECT pc.id, pc.release_id, pc.package, pc.change_type, pc.description, 
-			  COALESCE(pc.summary_ja, '') as summary_ja, COALESCE(pc.source_url, '') as source_url, pc.created_at 
+	query := `SELECT ` + packageChangeColumns + `
 			  FROM package_changes pc
 			  JOIN releases r ON pc.release_id = r.id
 			  ORDER BY r.release_date, pc.package`
@@ -234,119 +600,241 @@ func (d *Database) GetAllPackageChanges() ([]PackageChange, error) {
 	}
 	defer rows.Close()
 
-	var changes []PackageChange
-	for rows.Next() {
-		var c PackageChange
-		if err := rows.Scan(&c.ID, &c.ReleaseID, &c.Package, &c.ChangeType, &c.Description, &c.SummaryJa, &c.SourceURL, &c.CreatedAt); err != nil {
-			return nil, fmt.Errorf("failed to scan package change: %w", err)
+	return scanPackageChanges(rows)
+}
+
+// GetPackageEvolution はパッケージの変更を上書き設定を適用してリリース日順に返す
+// 上書き設定で別のパッケージから移された変更も含める
+// プレリリースの変更は includeUpcoming の場合のみ、正式リリース前のものを含める（/api/visualization と同じ）
+func (d *Database) GetPackageEvolution(packageName string, includeUpcoming bool) ([]PackageChange, error) {
+	overrides, err := d.loadOverrides()
+	if err != nil {
+		return nil, err
+	}
+	sources := []interface{}{packageName}
+	for _, o := range overrides {
+		if o.Package == packageName {
+			sources = append(sources, o.Key.Package)
 		}
-		changes = append(changes, c)
 	}
 
-	return changes, nil
-}
-
-func (d *Database) GetPackageEvolution(packageName string) ([]PackageChange, error) {
-	query := `SELECT pc.id, pc.release_id, pc.package, pc.change_type, pc.description, 
-			  COALESCE(pc.summary_ja, '') as summary_ja, COALESCE(pc.source_url, '') as source_url, pc.created_at 
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(sources)), ",")
+	query := `SELECT ` + packageChangeColumns + `
 			  FROM package_changes pc
 			  JOIN releases r ON pc.release_id = r.id
-			  WHERE pc.package = ?
+			  WHERE pc.package IN (` + placeholders + `)
 			  ORDER BY r.release_date`
-	rows, err := d.db.Query(query, packageName)
+	rows, err := d.db.Query(query, sources...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query package evolution: %w", err)
 	}
 	defer rows.Close()
 
-	var changes []PackageChange
-	for rows.Next() {
-		var c PackageChange
-		if err := rows.Scan(&c.ID, &c.ReleaseID, &c.Package, &c.ChangeType, &c.Description, &c.SummaryJa, &c.SourceURL, &c.CreatedAt); err != nil {
-			return nil, fmt.Errorf("failed to scan package change: %w", err)
-		}
-		changes = append(changes, c)
+	changes, err := scanPackageChanges(rows)
+	if err != nil {
+		return nil, err
+	}
+	curated, err := d.curate(changes)
+	if err != nil {
+		return nil, err
+	}
+	bases, err := d.GetBaseEntries(packageName)
+	if err != nil {
+		return nil, err
+	}
+	releases, err := d.GetAllReleases()
+	if err != nil {
+		return nil, err
 	}
 
-	return changes, nil
+	evolution := packageEvolutions(curated, bases, releases, visibleReleaseIDs(releases, includeUpcoming))[packageName]
+	if evolution == nil {
+		evolution = []PackageChange{}
+	}
+	return evolution, nil
 }
 
-func (d *Database) GetUniquePackages() ([]string, error) {
-	// パッケージをリリース日順に取得（各パッケージの最初のリリース日を基準）
-	query := `SELECT DISTINCT pc.package
-			  FROM package_changes pc
-			  JOIN releases r ON pc.release_id = r.id
-			  GROUP BY pc.package
-			  ORDER BY MIN(r.release_date)`
-	rows, err := d.db.Query(query)
-	if err != nil {
-		return nil, fmt.Errorf("failed to query unique packages: %w", err)
+// packageEvolutions は上書き設定を適用した変更 changes とベースエントリ bases を、
+// 対象のリリース（visible）の分だけパッケージごとにリリース日順にまとめる
+// ベースエントリは系列の最初のリリースの位置に加える
+func packageEvolutions(changes []PackageChange, bases []BaseEntry, releases []Release, visible map[int]bool) map[string][]PackageChange {
+	releaseDates := make(map[int]time.Time, len(releases))
+	for _, r := range releases {
+		releaseDates[r.ID] = r.ReleaseDate
 	}
-	defer rows.Close()
 
-	var packages []string
-	for rows.Next() {
-		var pkg string
-		if err := rows.Scan(&pkg); err != nil {
-			return nil, fmt.Errorf("failed to scan package: %w", err)
+	evolutions := make(map[string][]PackageChange)
+	for _, c := range changes {
+		if visible[c.ReleaseID] {
+			evolutions[c.Package] = append(evolutions[c.Package], c)
 		}
-		packages = append(packages, pkg)
 	}
+	for _, b := range bases {
+		if visible[b.ReleaseID] {
+			evolutions[b.Package] = append(evolutions[b.Package], b.Change())
+		}
+	}
+	for _, evolution := range evolutions {
+		sort.SliceStable(evolution, func(i, j int) bool {
+			return releaseDates[evolution[i].ReleaseID].Before(releaseDates[evolution[j].ReleaseID])
+		})
+	}
+	return evolutions
+}
 
-	return packages, nil
+// GetUniquePackages は変更のあるパッケージを最初のリリース日順に返す（上書き設定を適用する）
+func (d *Database) GetUniquePackages() ([]string, error) {
+	return d.GetPackagesInAreas(nil)
 }
 
-func (d *Database) GetVisualizationData() (map[string]interface{}, error) {
+func (d *Database) GetVisualizationData(opts VisualizationOptions) (map[string]interface{}, error) {
+	format := opts.DescriptionFormat
+	if format == "" {
+		format = DescriptionFormatText
+	}
+
 	releases, err := d.GetAllReleases()
 	if err != nil {
 		return nil, err
 	}
 
-	packages, err := d.GetUniquePackages()
+	// プレリリースは IncludeUpcoming の場合のみ、正式リリース前のものを含める
+	visible := visibleReleaseIDs(releases, opts.IncludeUpcoming)
+	visibleReleases := []Release{}
+	for _, release := range releases {
+		if visible[release.ID] {
+			visibleReleases = append(visibleReleases, release)
+		}
+	}
+	releases = visibleReleases
+
+	// 変更・ベースエントリはまとめて読み込み、パッケージごとに分ける
+	changes, err := d.GetCuratedPackageChanges()
 	if err != nil {
 		return nil, err
 	}
+	bases, err := d.GetBaseEntries("")
+	if err != nil {
+		return nil, err
+	}
+	evolutions := packageEvolutions(changes, bases, releases, visible)
+
+	// パッケージは最初のリリース日順（GetUniquePackages と同じ）
+	packages := []string{}
+	seen := make(map[string]bool)
+	for _, c := range changes {
+		if !seen[c.Package] {
+			seen[c.Package] = true
+			packages = append(packages, c.Package)
+		}
+	}
 
-	// パッケージごとの進化データを構築
+	releaseByID := make(map[int]Release, len(releases))
+	for _, release := range releases {
+		releaseByID[release.ID] = release
+	}
+
+	vulnIDs, err := d.GetChangeVulnerabilityIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := d.GetChangeLinks()
+	if err != nil {
+		return nil, err
+	}
+
+	platforms, err := d.GetChangePlatforms()
+	if err != nil {
+		return nil, err
+	}
+
+	translations, err := d.GetChangeTranslations()
+	if err != nil {
+		return nil, err
+	}
+
+	// パッケージごとの進化データを構築（領域・プラットフォームの条件に合う変更がないパッケージは除外）
 	packageEvolutions := make(map[string][]map[string]interface{})
+	visiblePackages := []string{}
 	
 	for _, pkg := range packages {
-		changes, err := d.GetPackageEvolution(pkg)
-		if err != nil {
-			continue
-		}
-
 		var timeline []map[string]interface{}
-		for _, change := range changes {
+		for _, change := range evolutions[pkg] {
+			if !opts.IncludesArea(change.Area) || !opts.IncludesPlatforms(platforms[change.ID]) {
+				continue
+			}
+
+			localized := change.Localized(translations[change.ID], opts.Languages)
+
 			// リリース情報を取得
-			for _, release := range releases {
-				if release.ID == change.ReleaseID {
-					timeline = append(timeline, map[string]interface{}{
-						"version":      release.Version,
-						"release_date": release.ReleaseDate,
-						"change_type":  change.ChangeType,
-						"description":  change.Description,
-						"summary_ja":   change.SummaryJa,
-						"source_url":   change.SourceURL,
-					})
-					break
-				}
+			if release, ok := releaseByID[change.ReleaseID]; ok {
+				timeline = append(timeline, map[string]interface{}{
+					"version":      release.Version,
+					"release_date": release.ReleaseDate,
+					"change_type":  change.ChangeType,
+					"change_type_confidence": change.ChangeTypeConfidence,
+					"description":  change.DescriptionAs(format),
+					"excerpt":      change.DescriptionAs(DescriptionFormatExcerpt),
+					"summary":      localized.Summary,
+					"summary_lang": localized.SummaryLang,
+					"summary_status": localized.SummaryStatus,
+					"source_url":   change.SourceURL,
+					"vuln_ids":     vulnIDs[change.ID],
+					"links":        links[change.ID],
+					"area":         change.Area,
+					"subheading":   change.Subheading,
+					"experiment":   change.Experiment,
+					"experiment_status": change.ExperimentStatus,
+					"platforms":    platformStrings(platforms[change.ID]),
+					"prerelease":   release.Prerelease,
+				})
 			}
 		}
+		if len(timeline) == 0 {
+			continue
+		}
 		packageEvolutions[pkg] = timeline
+		visiblePackages = append(visiblePackages, pkg)
+	}
+
+	experiments, err := d.GetExperiments()
+	if err != nil {
+		return nil, err
+	}
+
+	// パッケージごとの実験的機能と現在の状態
+	packageExperiments := make(map[string]map[string]string)
+	for _, exp := range experiments {
+		for _, pkg := range exp.Packages {
+			packageExperiments[pkg] = map[string]string{
+				"experiment": exp.Name,
+				"status":     exp.Status,
+			}
+		}
 	}
 
 	return map[string]interface{}{
-		"releases":          releases,
-		"packages":          packages,
-		"package_evolution": packageEvolutions,
+		"releases":            releases,
+		"packages":            visiblePackages,
+		"package_evolution":   packageEvolutions,
+		"package_experiments": packageExperiments,
 	}, nil
 }
 
 func (d *Database) ClearData() error {
 	queries := []string{
+		"DELETE FROM package_change_vulnerabilities",
+		"DELETE FROM change_links",
+		"DELETE FROM change_platforms",
+		"DELETE FROM change_translations",
+		"DELETE FROM godebug_events",
+		"DELETE FROM base_entries",
 		"DELETE FROM package_changes",
 		"DELETE FROM releases",
+		"DELETE FROM ingestion_release_snapshots",
+		"DELETE FROM ingestion_change_snapshots",
+		"DELETE FROM ingestion_godebug_snapshots",
 	}
 
 	for _, query := range queries {
@@ -358,6 +846,40 @@ func (d *Database) ClearData() error {
 	return nil
 }
 
+// GetPackagesInAreas は指定領域に変更を持つパッケージを最初のリリース日順に返す（空の場合はすべての領域）
+func (d *Database) GetPackagesInAreas(areas []string) ([]string, error) {
+	changes, err := d.GetCuratedPackageChanges()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := VisualizationOptions{Areas: areas}
+	seen := make(map[string]bool)
+	packages := []string{}
+	for _, c := range changes {
+		if seen[c.Package] || !opts.IncludesArea(c.Area) {
+			continue
+		}
+		seen[c.Package] = true
+		packages = append(packages, c.Package)
+	}
+	return packages, nil
+}
+
+// GetAreaCounts は領域ごとの変更数を返す（非表示にした変更は数えない）
+func (d *Database) GetAreaCounts() (map[string]int, error) {
+	changes, err := d.GetCuratedPackageChanges()
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	for _, c := range changes {
+		counts[c.Area]++
+	}
+	return counts, nil
+}
+
 // GetDB returns the underlying sql.DB for advanced operations
 func (d *Database) GetDB() *sql.DB {
 	return d.db
@@ -0,0 +1,50 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestGetPackageEvolutionUpcoming はパッケージの変更履歴でも、正式リリース前のプレリリースの変更を
+// includeUpcoming の場合のみ含め、ベースエントリを系列の最初のリリースの位置に加えることを確認する
+func TestGetPackageEvolutionUpcoming(t *testing.T) {
+	d := newTestDatabase(t)
+	saveTestChanges(t, d, "1.24")
+	saveTestChanges(t, d, "1.24.1", "net/http")
+	saveTestChanges(t, d, "1.25rc1", "net/http")
+	if _, err := d.RefreshBaseEntries(); err != nil {
+		t.Fatal(err)
+	}
+	// saveTestChanges は同じリリース日で保存するため、リリース日順になるよう日付を設定する
+	for i, version := range []string{"1.24", "1.24.1", "1.25rc1"} {
+		if _, err := d.db.Exec(`UPDATE releases SET release_date = ? WHERE version = ?`, time.Date(2025, 2, 11+i, 0, 0, 0, 0, time.UTC), version); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		upcoming bool
+		want     []string
+	}{
+		{upcoming: false, want: []string{"1.24 " + ChangeTypeBase, "1.24.1 Modified"}},
+		{upcoming: true, want: []string{"1.24 " + ChangeTypeBase, "1.24.1 Modified", "1.25rc1 Modified"}},
+	}
+	for _, tt := range tests {
+		changes, err := d.GetPackageEvolution("net/http", tt.upcoming)
+		if err != nil {
+			t.Fatal(err)
+		}
+		versions, err := d.releaseVersions()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, c := range changes {
+			got = append(got, versions[c.ReleaseID]+" "+c.ChangeType)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("upcoming=%v: evolution = %v, want %v", tt.upcoming, got, tt.want)
+		}
+	}
+}
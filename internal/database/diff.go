@@ -0,0 +1,104 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"go-ver-trace/internal/goversion"
+)
+
+// ErrInvalidRange は from が to より新しいなど、差分の範囲指定が不正な場合のエラー
+var ErrInvalidRange = errors.New("invalid release range")
+
+// ReleaseChanges はあるリリースでの変更一覧
+type ReleaseChanges struct {
+	Version    string          `json:"version"`
+	Prerelease bool            `json:"prerelease"` // 正式リリース前（今後入る予定の変更）
+	Changes    []PackageChange `json:"changes"`
+}
+
+// ReleaseDiff は from から to へ引き上げたときに含まれるリリースごとの変更
+type ReleaseDiff struct {
+	From     string           `json:"from"`
+	To       string           `json:"to"`
+	Releases []ReleaseChanges `json:"releases"`
+}
+
+// GetReleaseDiff は from より後、to 以前のリリースの変更を取得条件（領域・プラットフォーム）で絞り込んで返す
+// IncludeUpcoming の場合は正式リリース前のプレリリースも含める（to に "1.26" を指定すると "1.26rc1" も対象になる）
+func (d *Database) GetReleaseDiff(from, to string, opts VisualizationOptions) (ReleaseDiff, error) {
+	if _, err := goversion.Parse(from); err != nil {
+		return ReleaseDiff{}, fmt.Errorf("%w: %v", ErrInvalidVersion, err)
+	}
+	if _, err := goversion.Parse(to); err != nil {
+		return ReleaseDiff{}, fmt.Errorf("%w: %v", ErrInvalidVersion, err)
+	}
+	if goversion.Compare(from, to) > 0 {
+		return ReleaseDiff{}, fmt.Errorf("%w: from (%s) must not be newer than to (%s)", ErrInvalidRange, from, to)
+	}
+
+	format := opts.DescriptionFormat
+	if format == "" {
+		format = DescriptionFormatText
+	}
+
+	releases, err := d.GetAllReleases()
+	if err != nil {
+		return ReleaseDiff{}, err
+	}
+
+	links, err := d.GetChangeLinks()
+	if err != nil {
+		return ReleaseDiff{}, err
+	}
+
+	platforms, err := d.GetChangePlatforms()
+	if err != nil {
+		return ReleaseDiff{}, err
+	}
+
+	translations, err := d.GetChangeTranslations()
+	if err != nil {
+		return ReleaseDiff{}, err
+	}
+
+	visible := visibleReleaseIDs(releases, opts.IncludeUpcoming)
+	sort.SliceStable(releases, func(i, j int) bool {
+		return goversion.Compare(releases[i].Version, releases[j].Version) < 0
+	})
+
+	diff := ReleaseDiff{From: from, To: to, Releases: []ReleaseChanges{}}
+	for _, release := range releases {
+		if !visible[release.ID] || goversion.Compare(release.Version, from) <= 0 || goversion.Compare(release.Version, to) > 0 {
+			continue
+		}
+
+		changes, err := d.GetPackageChanges(release.ID)
+		if err == nil {
+			changes, err = d.curate(changes)
+		}
+		if err != nil {
+			return ReleaseDiff{}, err
+		}
+
+		filtered := []PackageChange{}
+		for _, change := range changes {
+			if !opts.IncludesArea(change.Area) || !opts.IncludesPlatforms(platforms[change.ID]) {
+				continue
+			}
+			change = change.Localized(translations[change.ID], opts.Languages)
+			change.Description = change.DescriptionAs(format)
+			change.Links = links[change.ID]
+			change.Platforms = platforms[change.ID]
+			filtered = append(filtered, change)
+		}
+		if len(filtered) == 0 {
+			continue
+		}
+
+		diff.Releases = append(diff.Releases, ReleaseChanges{Version: release.Version, Prerelease: release.Prerelease, Changes: filtered})
+	}
+
+	return diff, nil
+}
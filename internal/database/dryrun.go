@@ -0,0 +1,304 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go-ver-trace/internal/goversion"
+)
+
+// NewDryRun は livePath のデータベースをメモリ上に複製して開く
+// スクレイピングやインポートを複製に対して実行し、CompareDatabases で本番との差分を確認するために使う
+func NewDryRun(livePath string) (*Database, error) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open in-memory database: %w", err)
+	}
+	// :memory: のデータベースは接続ごとに別物になるため、接続を 1 本に限定する
+	db.SetMaxOpenConns(1)
+
+	database := &Database{db: db}
+	if err := database.createTables(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create tables: %w", err)
+	}
+	if err := database.copyFrom(livePath); err != nil {
+		db.Close()
+		return nil, err
+	}
+	// 移行前の本番データベースの summary_ja は複製に対して移す
+	if err := database.moveSummaryJa(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return database, nil
+}
+
+// copyFrom は livePath のデータベースの全テーブルの行を読み取り専用で取り込む
+// スキーマのバージョンが異なる場合に備えて、両方に存在するカラムのみを複製する
+func (d *Database) copyFrom(livePath string) error {
+	if _, err := d.db.Exec(`ATTACH DATABASE ? AS live`, "file:"+livePath+"?mode=ro"); err != nil {
+		return fmt.Errorf("failed to attach %s: %w", livePath, err)
+	}
+	defer d.db.Exec(`DETACH DATABASE live`)
+
+	tables, err := d.queryStrings(`SELECT name FROM live.sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'`)
+	if err != nil {
+		return fmt.Errorf("failed to list tables in %s: %w", livePath, err)
+	}
+
+	for _, table := range tables {
+		liveColumns, err := d.queryStrings(`SELECT name FROM pragma_table_info(?, 'live')`, table)
+		if err != nil {
+			return fmt.Errorf("failed to read columns of %s: %w", table, err)
+		}
+		mainColumns, err := d.queryStrings(`SELECT name FROM pragma_table_info(?, 'main')`, table)
+		if err != nil {
+			return fmt.Errorf("failed to read columns of %s: %w", table, err)
+		}
+
+		var columns []string
+		for _, column := range liveColumns {
+			for _, c := range mainColumns {
+				if c == column {
+					columns = append(columns, `"`+column+`"`)
+					break
+				}
+			}
+		}
+		if len(columns) == 0 {
+			continue
+		}
+
+		list := strings.Join(columns, ", ")
+		query := fmt.Sprintf(`INSERT INTO main."%s" (%s) SELECT %s FROM live."%s"`, table, list, list, table)
+		if _, err := d.db.Exec(query); err != nil {
+			return fmt.Errorf("failed to copy %s: %w", table, err)
+		}
+	}
+
+	// AUTOINCREMENT の採番を本番と揃える（削除済みの ID を再利用すると、リリースが存在しない変更が別のリリースに紐付くため）
+	if _, err := d.db.Exec(`DELETE FROM main.sqlite_sequence`); err != nil {
+		return fmt.Errorf("failed to reset sqlite_sequence: %w", err)
+	}
+	if _, err := d.db.Exec(`INSERT INTO main.sqlite_sequence (name, seq) SELECT name, seq FROM live.sqlite_sequence`); err != nil {
+		return fmt.Errorf("failed to copy sqlite_sequence: %w", err)
+	}
+	return nil
+}
+
+func (d *Database) queryStrings(query string, args ...interface{}) ([]string, error) {
+	rows, err := d.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var v string
+		if err := rows.Scan(&v); err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, rows.Err()
+}
+
+// ReleaseUpdate はバージョンが同じでリリース日・URL が異なるリリース
+type ReleaseUpdate struct {
+	Before Release `json:"before"`
+	After  Release `json:"after"`
+}
+
+// ChangeUpdate は同じリリース・領域・パッケージ・見出しで内容が異なる変更
+type ChangeUpdate struct {
+	Version string        `json:"version"`
+	Before  PackageChange `json:"before"`
+	After   PackageChange `json:"after"`
+}
+
+// VersionedChange はリリースのバージョン付きの変更
+type VersionedChange struct {
+	Version string `json:"version"`
+	PackageChange
+}
+
+// DatabaseDiff は本番のデータベースと試行結果の差分
+type DatabaseDiff struct {
+	AddedReleases   []Release         `json:"added_releases"`
+	ChangedReleases []ReleaseUpdate   `json:"changed_releases"`
+	RemovedReleases []Release         `json:"removed_releases"`
+	AddedChanges    []VersionedChange `json:"added_changes"`
+	ChangedChanges  []ChangeUpdate    `json:"changed_changes"`
+	RemovedChanges  []VersionedChange `json:"removed_changes"`
+}
+
+// Empty は差分がない場合に true を返す
+func (diff DatabaseDiff) Empty() bool {
+	return len(diff.AddedReleases) == 0 && len(diff.ChangedReleases) == 0 && len(diff.RemovedReleases) == 0 &&
+		len(diff.AddedChanges) == 0 && len(diff.ChangedChanges) == 0 && len(diff.RemovedChanges) == 0
+}
+
+// changeKey は変更を突き合わせるためのキー（ID は複製先で採番し直されるため使わない）
+type changeKey struct {
+	version, area, pkg, subheading string
+}
+
+// sameContent は変更の内容が同じかどうかを判定する
+func sameContent(a, b PackageChange) bool {
+	return a.ChangeType == b.ChangeType && a.Description == b.Description &&
+		a.Summary == b.Summary && a.SourceURL == b.SourceURL
+}
+
+// CompareDatabases は before から after への差分をリリースはバージョン、変更はリリース・領域・パッケージ・見出しで突き合わせて返す
+// 同じキーの変更が複数ある場合は、内容が一致するものを除いた残りを順に対応付ける
+func CompareDatabases(before, after *Database) (DatabaseDiff, error) {
+	diff := DatabaseDiff{
+		AddedReleases:   []Release{},
+		ChangedReleases: []ReleaseUpdate{},
+		RemovedReleases: []Release{},
+		AddedChanges:    []VersionedChange{},
+		ChangedChanges:  []ChangeUpdate{},
+		RemovedChanges:  []VersionedChange{},
+	}
+
+	beforeReleases, beforeChanges, err := before.snapshot()
+	if err != nil {
+		return diff, err
+	}
+	afterReleases, afterChanges, err := after.snapshot()
+	if err != nil {
+		return diff, err
+	}
+
+	for version, a := range afterReleases {
+		b, ok := beforeReleases[version]
+		switch {
+		case !ok:
+			diff.AddedReleases = append(diff.AddedReleases, a)
+		case !b.ReleaseDate.Equal(a.ReleaseDate) || b.URL != a.URL:
+			diff.ChangedReleases = append(diff.ChangedReleases, ReleaseUpdate{Before: b, After: a})
+		}
+	}
+	for version, b := range beforeReleases {
+		if _, ok := afterReleases[version]; !ok {
+			diff.RemovedReleases = append(diff.RemovedReleases, b)
+		}
+	}
+
+	keys := make(map[changeKey]bool)
+	for key := range beforeChanges {
+		keys[key] = true
+	}
+	for key := range afterChanges {
+		keys[key] = true
+	}
+
+	for key := range keys {
+		removed, added := unmatchedChanges(beforeChanges[key], afterChanges[key])
+		n := min(len(removed), len(added))
+		for i := 0; i < n; i++ {
+			diff.ChangedChanges = append(diff.ChangedChanges, ChangeUpdate{Version: key.version, Before: removed[i], After: added[i]})
+		}
+		for _, c := range added[n:] {
+			diff.AddedChanges = append(diff.AddedChanges, VersionedChange{Version: key.version, PackageChange: c})
+		}
+		for _, c := range removed[n:] {
+			diff.RemovedChanges = append(diff.RemovedChanges, VersionedChange{Version: key.version, PackageChange: c})
+		}
+	}
+
+	diff.sort()
+	return diff, nil
+}
+
+// unmatchedChanges は内容が一致する変更を取り除き、before と after それぞれの残りを返す
+func unmatchedChanges(before, after []PackageChange) ([]PackageChange, []PackageChange) {
+	matched := make([]bool, len(after))
+	var removed []PackageChange
+	for _, b := range before {
+		found := false
+		for i, a := range after {
+			if !matched[i] && sameContent(a, b) {
+				matched[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			removed = append(removed, b)
+		}
+	}
+
+	var added []PackageChange
+	for i, a := range after {
+		if !matched[i] {
+			added = append(added, a)
+		}
+	}
+	return removed, added
+}
+
+// snapshot はバージョンをキーとしたリリースと、突き合わせ用のキーで分類した変更を返す
+// リリースが存在しない変更（リリースの上書きで残った行）は対象外
+func (d *Database) snapshot() (map[string]Release, map[changeKey][]PackageChange, error) {
+	releases, err := d.GetAllReleases()
+	if err != nil {
+		return nil, nil, err
+	}
+	changes, err := d.GetAllPackageChanges()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	byVersion := make(map[string]Release)
+	versionByID := make(map[int]string)
+	for _, r := range releases {
+		byVersion[r.Version] = r
+		versionByID[r.ID] = r.Version
+	}
+
+	byKey := make(map[changeKey][]PackageChange)
+	for _, c := range changes {
+		key := changeKey{version: versionByID[c.ReleaseID], area: c.Area, pkg: c.Package, subheading: c.Subheading}
+		byKey[key] = append(byKey[key], c)
+	}
+	return byVersion, byKey, nil
+}
+
+func (diff *DatabaseDiff) sort() {
+	sortReleases := func(releases []Release) {
+		sort.Slice(releases, func(i, j int) bool {
+			return goversion.Compare(releases[i].Version, releases[j].Version) < 0
+		})
+	}
+	sortChanges := func(changes []VersionedChange) {
+		sort.Slice(changes, func(i, j int) bool {
+			if c := goversion.Compare(changes[i].Version, changes[j].Version); c != 0 {
+				return c < 0
+			}
+			if changes[i].Package != changes[j].Package {
+				return changes[i].Package < changes[j].Package
+			}
+			return changes[i].Description < changes[j].Description
+		})
+	}
+
+	sortReleases(diff.AddedReleases)
+	sortReleases(diff.RemovedReleases)
+	sort.Slice(diff.ChangedReleases, func(i, j int) bool {
+		return goversion.Compare(diff.ChangedReleases[i].After.Version, diff.ChangedReleases[j].After.Version) < 0
+	})
+	sortChanges(diff.AddedChanges)
+	sortChanges(diff.RemovedChanges)
+	sort.Slice(diff.ChangedChanges, func(i, j int) bool {
+		a, b := diff.ChangedChanges[i], diff.ChangedChanges[j]
+		if c := goversion.Compare(a.Version, b.Version); c != 0 {
+			return c < 0
+		}
+		return a.After.Package < b.After.Package
+	})
+}
@@ -0,0 +1,99 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+
+	"go-ver-trace/internal/goversion"
+)
+
+// ExperimentLifecycle はリリースノートから導出した実験的機能の経過
+type ExperimentLifecycle struct {
+	Name              string   `json:"name"`
+	Status            string   `json:"status"` // 最新リリース時点の状態
+	IntroducedVersion string   `json:"introduced_version"`
+	DefaultOnVersion  string   `json:"default_on_version,omitempty"`
+	RemovedVersion    string   `json:"removed_version,omitempty"`
+	Packages          []string `json:"packages"`
+	Versions          []string `json:"versions"` // 言及されたリリース
+}
+
+// GetExperiments は実験名ごとの導入・デフォルト有効化・削除バージョンを返す
+func (d *Database) GetExperiments() ([]ExperimentLifecycle, error) {
+	rows, err := d.db.Query(`SELECT pc.experiment, COALESCE(pc.experiment_status, ''), pc.package, r.version
+							 FROM package_changes pc
+							 JOIN releases r ON pc.release_id = r.id
+							 WHERE COALESCE(pc.experiment, '') != '' AND r.prerelease = 0`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query experiments: %w", err)
+	}
+	defer rows.Close()
+
+	type mention struct {
+		status  string
+		pkg     string
+		version string
+	}
+	byName := make(map[string][]mention)
+	for rows.Next() {
+		var name string
+		var m mention
+		if err := rows.Scan(&name, &m.status, &m.pkg, &m.version); err != nil {
+			return nil, fmt.Errorf("failed to scan experiment: %w", err)
+		}
+		byName[name] = append(byName[name], m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	experiments := []ExperimentLifecycle{}
+	for name, mentions := range byName {
+		sort.SliceStable(mentions, func(i, j int) bool {
+			return goversion.Compare(mentions[i].version, mentions[j].version) < 0
+		})
+
+		exp := ExperimentLifecycle{Name: name}
+		seenPackages := make(map[string]bool)
+		seenVersions := make(map[string]bool)
+		for _, m := range mentions {
+			if exp.IntroducedVersion == "" {
+				exp.IntroducedVersion = m.version
+			}
+			if m.status == "default_on" && exp.DefaultOnVersion == "" {
+				exp.DefaultOnVersion = m.version
+			}
+			if m.status == "removed" && exp.RemovedVersion == "" {
+				exp.RemovedVersion = m.version
+			}
+			if !seenPackages[m.pkg] {
+				seenPackages[m.pkg] = true
+				exp.Packages = append(exp.Packages, m.pkg)
+			}
+			if !seenVersions[m.version] {
+				seenVersions[m.version] = true
+				exp.Versions = append(exp.Versions, m.version)
+			}
+		}
+
+		switch {
+		case exp.RemovedVersion != "":
+			exp.Status = "removed"
+		case exp.DefaultOnVersion != "":
+			exp.Status = "default_on"
+		default:
+			exp.Status = "experimental"
+		}
+		sort.Strings(exp.Packages)
+		experiments = append(experiments, exp)
+	}
+
+	sort.Slice(experiments, func(i, j int) bool {
+		if c := goversion.Compare(experiments[i].IntroducedVersion, experiments[j].IntroducedVersion); c != 0 {
+			return c < 0
+		}
+		return experiments[i].Name < experiments[j].Name
+	})
+
+	return experiments, nil
+}
@@ -0,0 +1,119 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"go-ver-trace/internal/langtag"
+)
+
+// GlossaryTerm は用語集の 1 項目（英語の用語と言語ごとの訳語）
+// 訳語が用語と同じ場合は英語のまま残す用語（goroutine など）を表す
+type GlossaryTerm struct {
+	Term        string    `json:"term"`
+	Lang        string    `json:"lang"` // BCP-47 言語タグ
+	Translation string    `json:"translation"`
+	Note        string    `json:"note,omitempty"`
+	UpdatedBy   string    `json:"updated_by,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// GlossaryResult は用語集の保存結果の件数
+type GlossaryResult struct {
+	Created   int
+	Updated   int
+	Unchanged int
+}
+
+// migrateGlossary は用語集のテーブルを作成する
+func (d *Database) migrateGlossary() error {
+	_, err := d.db.Exec(`CREATE TABLE IF NOT EXISTS glossary_terms (
+			term TEXT NOT NULL COLLATE NOCASE,
+			lang TEXT NOT NULL,
+			translation TEXT NOT NULL,
+			note TEXT NOT NULL DEFAULT '',
+			updated_by TEXT NOT NULL DEFAULT '',
+			updated_at DATETIME NOT NULL,
+			PRIMARY KEY (term, lang)
+		)`)
+	if err != nil {
+		return fmt.Errorf("failed to migrate glossary: %w", err)
+	}
+	return nil
+}
+
+// ValidateGlossaryTerm は用語集の項目を検証し、言語タグを正規化して返す
+func ValidateGlossaryTerm(t GlossaryTerm) (GlossaryTerm, error) {
+	if t.Term == "" || t.Translation == "" {
+		return GlossaryTerm{}, fmt.Errorf("glossary term requires term and translation")
+	}
+	lang, err := langtag.Normalize(t.Lang)
+	if err != nil {
+		return GlossaryTerm{}, err
+	}
+	t.Lang = lang
+	return t, nil
+}
+
+// GetGlossary は lang の用語集を用語順に返す（lang が空の場合はすべての言語）
+func (d *Database) GetGlossary(lang string) ([]GlossaryTerm, error) {
+	rows, err := d.db.Query(`SELECT term, lang, translation, note, updated_by, updated_at FROM glossary_terms
+		WHERE ? = '' OR lang = ? ORDER BY lang, term`, lang, lang)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query glossary: %w", err)
+	}
+	defer rows.Close()
+
+	terms := []GlossaryTerm{}
+	for rows.Next() {
+		var t GlossaryTerm
+		if err := rows.Scan(&t.Term, &t.Lang, &t.Translation, &t.Note, &t.UpdatedBy, &t.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan glossary term: %w", err)
+		}
+		terms = append(terms, t)
+	}
+	return terms, rows.Err()
+}
+
+// SaveGlossaryTerms は用語集の項目を actor としてまとめて保存する（途中で失敗した場合は何も保存しない）
+// 用語は大文字・小文字を区別せずに突き合わせ、訳語・備考が同じ項目は更新しない
+func (d *Database) SaveGlossaryTerms(terms []GlossaryTerm, actor string) (GlossaryResult, error) {
+	var result GlossaryResult
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return result, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UTC()
+	for _, term := range terms {
+		t, err := ValidateGlossaryTerm(term)
+		if err != nil {
+			return GlossaryResult{}, fmt.Errorf("glossary term %q: %w", term.Term, err)
+		}
+
+		var translation, note string
+		err = tx.QueryRow(`SELECT translation, note FROM glossary_terms WHERE term = ? AND lang = ?`, t.Term, t.Lang).Scan(&translation, &note)
+		switch {
+		case err != nil && err != sql.ErrNoRows:
+			return GlossaryResult{}, fmt.Errorf("failed to query glossary term %q (%s): %w", t.Term, t.Lang, err)
+		case err == nil && translation == t.Translation && note == t.Note:
+			result.Unchanged++
+			continue
+		case err == nil:
+			_, err = tx.Exec(`UPDATE glossary_terms SET term = ?, translation = ?, note = ?, updated_by = ?, updated_at = ? WHERE term = ? AND lang = ?`,
+				t.Term, t.Translation, t.Note, actor, now, t.Term, t.Lang)
+			result.Updated++
+		default:
+			_, err = tx.Exec(`INSERT INTO glossary_terms (term, lang, translation, note, updated_by, updated_at) VALUES (?, ?, ?, ?, ?, ?)`,
+				t.Term, t.Lang, t.Translation, t.Note, actor, now)
+			result.Created++
+		}
+		if err != nil {
+			return GlossaryResult{}, fmt.Errorf("failed to save glossary term %q (%s): %w", t.Term, t.Lang, err)
+		}
+	}
+	return result, tx.Commit()
+}
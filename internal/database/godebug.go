@@ -0,0 +1,140 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"go-ver-trace/internal/goversion"
+)
+
+// ErrInvalidVersion は指定された Go のバージョンを解析できない場合のエラー
+var ErrInvalidVersion = errors.New("invalid version")
+
+// GodebugEvent はあるリリースでの GODEBUG 設定の導入・デフォルト変更・削除
+type GodebugEvent struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Kind        string `json:"kind"`  // introduced / default_changed / removed
+	Value       string `json:"value"` // 旧動作に戻すための値
+	Package     string `json:"package"`
+	Description string `json:"description"`
+}
+
+// GodebugSetting はリリースをまたいだ GODEBUG 設定の履歴
+type GodebugSetting struct {
+	Name              string         `json:"name"`
+	Package           string         `json:"package"`
+	IntroducedVersion string         `json:"introduced_version"`
+	RemovedVersion    string         `json:"removed_version,omitempty"`
+	Events            []GodebugEvent `json:"events"`
+}
+
+// SaveGodebugEvent は GODEBUG 設定のイベントを保存する（同一設定・同一バージョンは上書き）
+// 実行中に上書きした場合は、取り消しで元に戻せるよう上書きする前の状態を保存する
+func (d *Database) SaveGodebugEvent(runID int, e GodebugEvent) error {
+	if err := d.snapshotGodebugEvent(d.db, runID, e.Name, e.Version); err != nil {
+		return err
+	}
+	query := `INSERT INTO godebug_events (name, version, kind, value, package, description, ingestion_run_id)
+			  VALUES (?, ?, ?, ?, ?, ?, NULLIF(?, 0))
+			  ON CONFLICT (name, version) DO UPDATE SET
+			      kind = excluded.kind,
+			      value = COALESCE(NULLIF(excluded.value, ''), godebug_events.value),
+			      package = excluded.package,
+			      description = excluded.description,
+			      ingestion_run_id = excluded.ingestion_run_id`
+	_, err := d.db.Exec(query, e.Name, e.Version, e.Kind, e.Value, e.Package, e.Description, runID)
+	if err != nil {
+		return fmt.Errorf("failed to save GODEBUG event %s (%s): %w", e.Name, e.Version, err)
+	}
+	return nil
+}
+
+// GetGodebugSettings は設定ごとの履歴を返す
+// 最初に言及されたリリースを導入バージョンとし、そのイベントの種別を introduced とする
+func (d *Database) GetGodebugSettings() ([]GodebugSetting, error) {
+	rows, err := d.db.Query(`SELECT name, version, kind, COALESCE(value, ''), COALESCE(package, ''), COALESCE(description, '')
+							 FROM godebug_events`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query GODEBUG events: %w", err)
+	}
+	defer rows.Close()
+
+	byName := make(map[string][]GodebugEvent)
+	for rows.Next() {
+		var e GodebugEvent
+		if err := rows.Scan(&e.Name, &e.Version, &e.Kind, &e.Value, &e.Package, &e.Description); err != nil {
+			return nil, fmt.Errorf("failed to scan GODEBUG event: %w", err)
+		}
+		byName[e.Name] = append(byName[e.Name], e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var settings []GodebugSetting
+	for name, events := range byName {
+		sort.Slice(events, func(i, j int) bool {
+			return goversion.Compare(events[i].Version, events[j].Version) < 0
+		})
+
+		setting := GodebugSetting{Name: name}
+		for i := range events {
+			if setting.Package == "" {
+				setting.Package = events[i].Package
+			}
+			switch {
+			case events[i].Kind == "removed":
+				if setting.RemovedVersion == "" {
+					setting.RemovedVersion = events[i].Version
+				}
+			case setting.IntroducedVersion == "":
+				setting.IntroducedVersion = events[i].Version
+				events[i].Kind = "introduced"
+			}
+		}
+		setting.Events = events
+		settings = append(settings, setting)
+	}
+
+	sort.Slice(settings, func(i, j int) bool {
+		if c := goversion.Compare(settings[i].IntroducedVersion, settings[j].IntroducedVersion); c != 0 {
+			return c < 0
+		}
+		return settings[i].Name < settings[j].Name
+	})
+
+	return settings, nil
+}
+
+// GetGodebugFlips は go.mod の go 行が goVersion のモジュールを toolchain まで引き上げたときに
+// デフォルト動作が切り替わる（または旧動作に戻せなくなる）GODEBUG 設定のイベントを返す
+func (d *Database) GetGodebugFlips(goVersion, toolchain string) ([]GodebugEvent, error) {
+	if _, err := goversion.Parse(goVersion); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidVersion, err)
+	}
+	if _, err := goversion.Parse(toolchain); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidVersion, err)
+	}
+
+	settings, err := d.GetGodebugSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	flips := []GodebugEvent{}
+	for _, setting := range settings {
+		for _, event := range setting.Events {
+			if goversion.Compare(event.Version, goVersion) > 0 && goversion.Compare(event.Version, toolchain) <= 0 {
+				flips = append(flips, event)
+			}
+		}
+	}
+
+	sort.SliceStable(flips, func(i, j int) bool {
+		return goversion.Compare(flips[i].Version, flips[j].Version) < 0
+	})
+
+	return flips, nil
+}
@@ -0,0 +1,334 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// IngestionRun はスクレイピング・インポート 1 回分の実行記録
+type IngestionRun struct {
+	ID           int        `json:"id"`
+	StartedAt    time.Time  `json:"started_at"`
+	FinishedAt   *time.Time `json:"finished_at,omitempty"`
+	Status       string     `json:"status"`      // running / succeeded / failed / rolled_back
+	SourceType   string     `json:"source_type"` // scrape / import-json / create-base / import-osv / import-markdown
+	Source       string     `json:"source"`      // 取得元の URL またはファイルパス
+	SourceHash   string     `json:"source_hash,omitempty"`
+	ToolVersion  string     `json:"tool_version"`
+	ReleaseCount int        `json:"release_count"`
+	ChangeCount  int        `json:"change_count"`
+	CacheHits    int        `json:"cache_hits"`               // 更新されておらず HTTP キャッシュを使ったページの数
+	RolledBackBy string     `json:"rolled_back_by,omitempty"` // 取り消した操作者
+	RolledBackAt *time.Time `json:"rolled_back_at,omitempty"`
+}
+
+// IngestionSource は実行記録の取得元
+type IngestionSource struct {
+	Type        string
+	Source      string
+	Hash        string // ファイルの場合は内容の SHA-256
+	ToolVersion string
+}
+
+// 実行記録の取得元の種別
+const (
+	SourceScrape         = "scrape"
+	SourceImportJSON     = "import-json"
+	SourceCreateBase     = "create-base" // 以前の -create-base（ベースエントリは取り込みのたびに導出する）
+	SourceImportOSV      = "import-osv"
+	SourceImportMarkdown = "import-markdown"
+)
+
+// 実行記録の状態
+const (
+	IngestionRunning    = "running"
+	IngestionSucceeded  = "succeeded"
+	IngestionFailed     = "failed"
+	IngestionRolledBack = "rolled_back"
+)
+
+// RunStateError は取り消せない状態（取り消し済み・実行中）の実行記録を取り消そうとした場合のエラー
+type RunStateError struct {
+	RunID  int
+	Status string
+}
+
+func (e *RunStateError) Error() string {
+	return fmt.Sprintf("ingestion run %d cannot be rolled back (status: %s)", e.RunID, e.Status)
+}
+
+// IngestionDiagnostic はリリースノートの解析中に見つかった問題
+type IngestionDiagnostic struct {
+	ID      int    `json:"id"`
+	RunID   int    `json:"run_id"`
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+	Subject string `json:"subject"`
+	Detail  string `json:"detail"`
+}
+
+// IngestionDiagnosticsReport は実行記録ごとの診断一覧と種別ごとの件数
+type IngestionDiagnosticsReport struct {
+	Run         IngestionRun          `json:"run"`
+	Summary     map[string]int        `json:"summary"`
+	Diagnostics []IngestionDiagnostic `json:"diagnostics"`
+}
+
+// RollbackResult は実行記録の取り消しで削除・復元した行数
+type RollbackResult struct {
+	RunID                 int `json:"run_id"`
+	DeletedReleases       int `json:"deleted_releases"`
+	DeletedChanges        int `json:"deleted_changes"`
+	DeletedGodebugEvents  int `json:"deleted_godebug_events"`
+	RestoredReleases      int `json:"restored_releases"`       // 実行が上書きしたリリース
+	RestoredChanges       int `json:"restored_changes"`        // 実行が Security Fix にした既存の変更
+	RestoredGodebugEvents int `json:"restored_godebug_events"` // 実行が上書きした GODEBUG 設定のイベント
+}
+
+// migrateIngestionProvenance は実行記録の取得元カラムと、各行の ingestion_run_id カラムを追加する
+func (d *Database) migrateIngestionProvenance() error {
+	columns := []struct{ table, column, definition string }{
+		{"ingestion_runs", "source_type", "TEXT NOT NULL DEFAULT '" + SourceScrape + "'"},
+		{"ingestion_runs", "source", "TEXT NOT NULL DEFAULT ''"},
+		{"ingestion_runs", "source_hash", "TEXT"},
+		{"ingestion_runs", "tool_version", "TEXT NOT NULL DEFAULT ''"},
+		{"ingestion_runs", "cache_hits", "INTEGER NOT NULL DEFAULT 0"},
+		{"ingestion_runs", "rolled_back_by", "TEXT NOT NULL DEFAULT ''"},
+		{"ingestion_runs", "rolled_back_at", "DATETIME"},
+		{"releases", "ingestion_run_id", "INTEGER REFERENCES ingestion_runs (id)"},
+		{"package_changes", "ingestion_run_id", "INTEGER REFERENCES ingestion_runs (id)"},
+	}
+	for _, c := range columns {
+		if err := d.addColumnIfNotExists(c.table, c.column, c.definition); err != nil {
+			return fmt.Errorf("failed to migrate %s.%s column: %w", c.table, c.column, err)
+		}
+	}
+
+	if _, err := d.db.Exec(`CREATE INDEX IF NOT EXISTS idx_package_changes_ingestion_run_id ON package_changes (ingestion_run_id)`); err != nil {
+		return fmt.Errorf("failed to create ingestion_run_id index: %w", err)
+	}
+	return nil
+}
+
+// StartIngestionRun は実行記録を作成して ID を返す
+// 保存時にこの ID を指定した releases・package_changes の行がこの実行記録に紐付く
+func (d *Database) StartIngestionRun(src IngestionSource) (int, error) {
+	result, err := d.db.Exec(`INSERT INTO ingestion_runs (started_at, status, source_type, source, source_hash, tool_version)
+							  VALUES (?, ?, ?, ?, ?, ?)`,
+		time.Now(), IngestionRunning, src.Type, src.Source, src.Hash, src.ToolVersion)
+	if err != nil {
+		return 0, fmt.Errorf("failed to start ingestion run: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(id), nil
+}
+
+// FinishIngestionRun は実行記録に終了時刻・状態と、紐付いた行数を記録し、ベースエントリを導出し直す
+func (d *Database) FinishIngestionRun(runID int, status string) error {
+	_, err := d.db.Exec(`UPDATE ingestion_runs
+						 SET finished_at = ?, status = ?,
+						     release_count = (SELECT COUNT(*) FROM releases WHERE ingestion_run_id = ingestion_runs.id),
+						     change_count = (SELECT COUNT(*) FROM package_changes WHERE ingestion_run_id = ingestion_runs.id)
+						 WHERE id = ?`,
+		time.Now(), status, runID)
+	if err != nil {
+		return fmt.Errorf("failed to finish ingestion run %d: %w", runID, err)
+	}
+	_, err = d.RefreshBaseEntries()
+	return err
+}
+
+// RecordIngestionCacheHits は実行記録に HTTP キャッシュを使ったページの数を記録する
+func (d *Database) RecordIngestionCacheHits(runID, hits int) error {
+	if _, err := d.db.Exec(`UPDATE ingestion_runs SET cache_hits = ? WHERE id = ?`, hits, runID); err != nil {
+		return fmt.Errorf("failed to record cache hits for ingestion run %d: %w", runID, err)
+	}
+	return nil
+}
+
+const ingestionRunColumns = `id, started_at, finished_at, status, source_type, source, COALESCE(source_hash, ''),
+			  tool_version, release_count, change_count, cache_hits, rolled_back_by, rolled_back_at`
+
+func scanIngestionRun(scan func(dest ...interface{}) error) (IngestionRun, error) {
+	var run IngestionRun
+	var finishedAt, rolledBackAt sql.NullTime
+	err := scan(&run.ID, &run.StartedAt, &finishedAt, &run.Status, &run.SourceType, &run.Source, &run.SourceHash,
+		&run.ToolVersion, &run.ReleaseCount, &run.ChangeCount, &run.CacheHits, &run.RolledBackBy, &rolledBackAt)
+	if err != nil {
+		return IngestionRun{}, err
+	}
+	if finishedAt.Valid {
+		run.FinishedAt = &finishedAt.Time
+	}
+	if rolledBackAt.Valid {
+		run.RolledBackAt = &rolledBackAt.Time
+	}
+	return run, nil
+}
+
+// GetIngestionRuns は実行記録を新しい順に返す
+func (d *Database) GetIngestionRuns() ([]IngestionRun, error) {
+	rows, err := d.db.Query(`SELECT ` + ingestionRunColumns + ` FROM ingestion_runs ORDER BY id DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ingestion runs: %w", err)
+	}
+	defer rows.Close()
+
+	runs := []IngestionRun{}
+	for rows.Next() {
+		run, err := scanIngestionRun(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan ingestion run: %w", err)
+		}
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
+
+// GetIngestionRun は実行記録を返す（存在しない場合は sql.ErrNoRows）
+func (d *Database) GetIngestionRun(runID int) (IngestionRun, error) {
+	return scanIngestionRun(d.db.QueryRow(`SELECT `+ingestionRunColumns+` FROM ingestion_runs WHERE id = ?`, runID).Scan)
+}
+
+// RollbackIngestionRun は実行記録に紐付く変更・GODEBUG 設定のイベントと、変更が残っていないリリースを削除し、
+// 実行が書き換えた既存のリリース・変更・GODEBUG 設定のイベントを書き換える前の状態に戻して、actor が取り消したことを記録する
+// 後の実行で上書きされたリリースは後の実行に紐付くため削除しない
+// 取り消し済み・実行中の実行記録は *RunStateError を返す
+func (d *Database) RollbackIngestionRun(runID int, actor string) (RollbackResult, error) {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return RollbackResult{}, err
+	}
+	defer tx.Rollback()
+
+	var status string
+	if err := tx.QueryRow(`SELECT status FROM ingestion_runs WHERE id = ?`, runID).Scan(&status); err != nil {
+		return RollbackResult{}, err
+	}
+	if status == IngestionRolledBack || status == IngestionRunning {
+		return RollbackResult{}, &RunStateError{RunID: runID, Status: status}
+	}
+
+	runChanges := `SELECT id FROM package_changes WHERE ingestion_run_id = ?`
+	for _, table := range []string{"change_links", "change_platforms", "change_translations", "package_change_vulnerabilities"} {
+		if _, err := tx.Exec(`DELETE FROM `+table+` WHERE change_id IN (`+runChanges+`)`, runID); err != nil {
+			return RollbackResult{}, fmt.Errorf("failed to delete %s for run %d: %w", table, runID, err)
+		}
+	}
+
+	// 削除する正式リリースの変更に対応付けたプレリリースの変更は未対応に戻す
+	if _, err := tx.Exec(`UPDATE package_changes SET ga_change_id = NULL WHERE ga_change_id IN (`+runChanges+`)`, runID); err != nil {
+		return RollbackResult{}, fmt.Errorf("failed to unlink prerelease changes for run %d: %w", runID, err)
+	}
+
+	result := RollbackResult{RunID: runID}
+	res, err := tx.Exec(`DELETE FROM package_changes WHERE ingestion_run_id = ?`, runID)
+	if err != nil {
+		return RollbackResult{}, fmt.Errorf("failed to delete package changes for run %d: %w", runID, err)
+	}
+	n, _ := res.RowsAffected()
+	result.DeletedChanges = int(n)
+
+	res, err = tx.Exec(`DELETE FROM releases WHERE ingestion_run_id = ?
+						AND NOT EXISTS (SELECT 1 FROM package_changes pc WHERE pc.release_id = releases.id)`, runID)
+	if err != nil {
+		return RollbackResult{}, fmt.Errorf("failed to delete releases for run %d: %w", runID, err)
+	}
+	n, _ = res.RowsAffected()
+	result.DeletedReleases = int(n)
+
+	restored, err := restoreSnapshots(tx, runID)
+	if err != nil {
+		return RollbackResult{}, err
+	}
+	result.DeletedGodebugEvents = restored.DeletedGodebugEvents
+	result.RestoredReleases = restored.RestoredReleases
+	result.RestoredChanges = restored.RestoredChanges
+	result.RestoredGodebugEvents = restored.RestoredGodebugEvents
+
+	if _, err := tx.Exec(`UPDATE ingestion_runs SET status = ?, rolled_back_by = ?, rolled_back_at = ? WHERE id = ?`,
+		IngestionRolledBack, actor, time.Now(), runID); err != nil {
+		return RollbackResult{}, fmt.Errorf("failed to update ingestion run %d: %w", runID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return RollbackResult{}, err
+	}
+	if _, err := d.RefreshBaseEntries(); err != nil {
+		return RollbackResult{}, err
+	}
+	return result, nil
+}
+
+// SaveIngestionDiagnostics は実行記録に診断を追加する
+func (d *Database) SaveIngestionDiagnostics(runID int, diagnostics []IngestionDiagnostic) error {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`INSERT INTO ingestion_diagnostics (run_id, version, kind, subject, detail)
+							 VALUES (?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, diag := range diagnostics {
+		if _, err := stmt.Exec(runID, diag.Version, diag.Kind, diag.Subject, diag.Detail); err != nil {
+			return fmt.Errorf("failed to save diagnostic for Go %s: %w", diag.Version, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// GetIngestionDiagnostics は実行記録の診断を返す（kind・version が空でなければ絞り込む）
+func (d *Database) GetIngestionDiagnostics(runID int, kind, version string) (IngestionDiagnosticsReport, error) {
+	run, err := d.GetIngestionRun(runID)
+	if err != nil {
+		return IngestionDiagnosticsReport{}, err
+	}
+
+	query := `SELECT id, run_id, version, kind, subject, COALESCE(detail, '')
+			  FROM ingestion_diagnostics WHERE run_id = ?`
+	args := []interface{}{runID}
+	if kind != "" {
+		query += " AND kind = ?"
+		args = append(args, kind)
+	}
+	if version != "" {
+		query += " AND version = ?"
+		args = append(args, version)
+	}
+	query += " ORDER BY id"
+
+	rows, err := d.db.Query(query, args...)
+	if err != nil {
+		return IngestionDiagnosticsReport{}, fmt.Errorf("failed to query diagnostics: %w", err)
+	}
+	defer rows.Close()
+
+	report := IngestionDiagnosticsReport{
+		Run:         run,
+		Summary:     make(map[string]int),
+		Diagnostics: []IngestionDiagnostic{},
+	}
+	for rows.Next() {
+		var diag IngestionDiagnostic
+		if err := rows.Scan(&diag.ID, &diag.RunID, &diag.Version, &diag.Kind, &diag.Subject, &diag.Detail); err != nil {
+			return IngestionDiagnosticsReport{}, fmt.Errorf("failed to scan diagnostic: %w", err)
+		}
+		report.Summary[diag.Kind]++
+		report.Diagnostics = append(report.Diagnostics, diag)
+	}
+	if err := rows.Err(); err != nil {
+		return IngestionDiagnosticsReport{}, err
+	}
+	return report, nil
+}
@@ -0,0 +1,169 @@
+package database
+
+import (
+	"fmt"
+)
+
+// 実行が既存の行を書き換える前の状態を実行記録ごとに保存し、取り消しで元に戻す
+//   - releases: SaveRelease（INSERT OR REPLACE）で上書きしたリリース
+//   - package_changes: ImportVulnerabilities で Security Fix にした変更の種別・確信度・source_url
+//   - godebug_events: SaveGodebugEvent で上書きした GODEBUG 設定のイベント
+// 同じ実行で同じ行を何度書き換えても、保存するのは最初の状態だけ
+
+// migrateIngestionSnapshots は書き換える前の行を保存するテーブルと、
+// godebug_events・package_change_vulnerabilities の ingestion_run_id カラムを追加する
+func (d *Database) migrateIngestionSnapshots() error {
+	tables := []string{
+		`CREATE TABLE IF NOT EXISTS ingestion_release_snapshots (
+			run_id INTEGER NOT NULL REFERENCES ingestion_runs (id),
+			release_id INTEGER NOT NULL,
+			version TEXT NOT NULL,
+			release_date DATETIME NOT NULL,
+			url TEXT NOT NULL,
+			prerelease BOOLEAN NOT NULL DEFAULT 0,
+			ingestion_run_id INTEGER,
+			created_at DATETIME,
+			PRIMARY KEY (run_id, version)
+		)`,
+		`CREATE TABLE IF NOT EXISTS ingestion_change_snapshots (
+			run_id INTEGER NOT NULL REFERENCES ingestion_runs (id),
+			change_id INTEGER NOT NULL,
+			change_type TEXT NOT NULL,
+			change_type_confidence REAL,
+			source_url TEXT,
+			PRIMARY KEY (run_id, change_id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS ingestion_godebug_snapshots (
+			run_id INTEGER NOT NULL REFERENCES ingestion_runs (id),
+			name TEXT NOT NULL,
+			version TEXT NOT NULL,
+			kind TEXT NOT NULL,
+			value TEXT,
+			package TEXT,
+			description TEXT,
+			ingestion_run_id INTEGER,
+			PRIMARY KEY (run_id, name, version)
+		)`,
+	}
+	for _, query := range tables {
+		if _, err := d.db.Exec(query); err != nil {
+			return fmt.Errorf("failed to migrate ingestion snapshots: %w", err)
+		}
+	}
+
+	for _, table := range []string{"godebug_events", "package_change_vulnerabilities"} {
+		if err := d.addColumnIfNotExists(table, "ingestion_run_id", "INTEGER REFERENCES ingestion_runs (id)"); err != nil {
+			return fmt.Errorf("failed to migrate %s.ingestion_run_id column: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// snapshotRelease は実行中に version のリリースを上書きする前に、他の実行で保存したリリースの状態を保存する
+func (d *Database) snapshotRelease(exec execer, runID int, version string) error {
+	if runID == 0 {
+		return nil
+	}
+	_, err := exec.Exec(`INSERT OR IGNORE INTO ingestion_release_snapshots
+			(run_id, release_id, version, release_date, url, prerelease, ingestion_run_id, created_at)
+		SELECT ?, id, version, release_date, url, prerelease, ingestion_run_id, created_at
+		FROM releases WHERE version = ? AND COALESCE(ingestion_run_id, 0) != ?`,
+		runID, version, runID)
+	if err != nil {
+		return fmt.Errorf("failed to snapshot release %s: %w", version, err)
+	}
+	return nil
+}
+
+// snapshotChange は実行中に他の実行で保存した変更の種別を書き換える前に、その状態を保存する
+func (d *Database) snapshotChange(exec execer, runID, changeID int) error {
+	if runID == 0 {
+		return nil
+	}
+	_, err := exec.Exec(`INSERT OR IGNORE INTO ingestion_change_snapshots (run_id, change_id, change_type, change_type_confidence, source_url)
+		SELECT ?, id, change_type, change_type_confidence, source_url
+		FROM package_changes WHERE id = ? AND COALESCE(ingestion_run_id, 0) != ?`,
+		runID, changeID, runID)
+	if err != nil {
+		return fmt.Errorf("failed to snapshot change %d: %w", changeID, err)
+	}
+	return nil
+}
+
+// snapshotGodebugEvent は実行中に他の実行で保存した GODEBUG 設定のイベントを上書きする前に、その状態を保存する
+func (d *Database) snapshotGodebugEvent(exec execer, runID int, name, version string) error {
+	if runID == 0 {
+		return nil
+	}
+	_, err := exec.Exec(`INSERT OR IGNORE INTO ingestion_godebug_snapshots
+			(run_id, name, version, kind, value, package, description, ingestion_run_id)
+		SELECT ?, name, version, kind, value, package, description, ingestion_run_id
+		FROM godebug_events WHERE name = ? AND version = ? AND COALESCE(ingestion_run_id, 0) != ?`,
+		runID, name, version, runID)
+	if err != nil {
+		return fmt.Errorf("failed to snapshot GODEBUG event %s (%s): %w", name, version, err)
+	}
+	return nil
+}
+
+// restoreSnapshots は実行記録が書き換えた行を書き換える前の状態に戻す
+// 後の実行がさらに書き換えた行は後の実行の状態を残す（後の実行を取り消すと、その実行が保存した状態に戻る）
+func restoreSnapshots(tx execer, runID int) (RollbackResult, error) {
+	var result RollbackResult
+
+	// 上書きしたリリースを元の ID で戻し、上書き後のリリースに付いた他の実行の変更を元のリリースに付け替える
+	replaced := `SELECT r.id FROM releases r JOIN ingestion_release_snapshots s ON s.version = r.version
+		WHERE s.run_id = ? AND r.ingestion_run_id = ?`
+	if _, err := tx.Exec(`UPDATE package_changes SET release_id = (
+			SELECT s.release_id FROM releases r JOIN ingestion_release_snapshots s ON s.version = r.version
+			WHERE s.run_id = ? AND r.id = package_changes.release_id)
+		WHERE release_id IN (`+replaced+`)`, runID, runID, runID); err != nil {
+		return result, fmt.Errorf("failed to move changes to restored releases for run %d: %w", runID, err)
+	}
+	if _, err := tx.Exec(`DELETE FROM releases WHERE id IN (`+replaced+`)`, runID, runID); err != nil {
+		return result, fmt.Errorf("failed to delete replaced releases for run %d: %w", runID, err)
+	}
+	res, err := tx.Exec(`INSERT INTO releases (id, version, release_date, url, prerelease, ingestion_run_id, created_at)
+		SELECT release_id, version, release_date, url, prerelease, ingestion_run_id, created_at
+		FROM ingestion_release_snapshots s
+		WHERE s.run_id = ? AND NOT EXISTS (SELECT 1 FROM releases r WHERE r.version = s.version)`, runID)
+	if err != nil {
+		return result, fmt.Errorf("failed to restore releases for run %d: %w", runID, err)
+	}
+	n, _ := res.RowsAffected()
+	result.RestoredReleases = int(n)
+
+	// Security Fix にした変更は、後の実行が書き換えていなければ元の種別に戻す
+	res, err = tx.Exec(`UPDATE package_changes SET
+			change_type = s.change_type, change_type_confidence = s.change_type_confidence, source_url = s.source_url
+		FROM ingestion_change_snapshots s
+		WHERE s.run_id = ? AND s.change_id = package_changes.id
+		AND NOT EXISTS (SELECT 1 FROM ingestion_change_snapshots later JOIN ingestion_runs r ON r.id = later.run_id
+			WHERE later.change_id = s.change_id AND later.run_id > s.run_id AND r.status != ?)`, runID, IngestionRolledBack)
+	if err != nil {
+		return result, fmt.Errorf("failed to restore changes for run %d: %w", runID, err)
+	}
+	n, _ = res.RowsAffected()
+	result.RestoredChanges = int(n)
+	if _, err := tx.Exec(`DELETE FROM package_change_vulnerabilities WHERE ingestion_run_id = ?`, runID); err != nil {
+		return result, fmt.Errorf("failed to delete vulnerability links for run %d: %w", runID, err)
+	}
+
+	// 実行が保存した GODEBUG 設定のイベントを削除し、上書きしたイベントを戻す
+	res, err = tx.Exec(`DELETE FROM godebug_events WHERE ingestion_run_id = ?`, runID)
+	if err != nil {
+		return result, fmt.Errorf("failed to delete GODEBUG events for run %d: %w", runID, err)
+	}
+	n, _ = res.RowsAffected()
+	result.DeletedGodebugEvents = int(n)
+	res, err = tx.Exec(`INSERT INTO godebug_events (name, version, kind, value, package, description, ingestion_run_id)
+		SELECT name, version, kind, value, package, description, ingestion_run_id
+		FROM ingestion_godebug_snapshots s
+		WHERE s.run_id = ? AND NOT EXISTS (SELECT 1 FROM godebug_events e WHERE e.name = s.name AND e.version = s.version)`, runID)
+	if err != nil {
+		return result, fmt.Errorf("failed to restore GODEBUG events for run %d: %w", runID, err)
+	}
+	n, _ = res.RowsAffected()
+	result.RestoredGodebugEvents = int(n)
+	return result, nil
+}
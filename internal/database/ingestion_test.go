@@ -0,0 +1,177 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestDatabase(t *testing.T) *Database {
+	t.Helper()
+	d, err := New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { d.Close() })
+	return d
+}
+
+func startTestRun(t *testing.T, d *Database) int {
+	t.Helper()
+	runID, err := d.StartIngestionRun(IngestionSource{Type: SourceScrape, Source: "https://go.dev/doc/devel/release"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return runID
+}
+
+// TestRollbackIngestionRun は取り込み直した実行を取り消すと、上書きされたリリース・
+// Security Fix にした変更・GODEBUG 設定のイベントが前の実行の状態に戻ることを確認する
+func TestRollbackIngestionRun(t *testing.T) {
+	d := newTestDatabase(t)
+	date := time.Date(2024, 2, 6, 0, 0, 0, 0, time.UTC)
+
+	// 1 回目の取り込み
+	first := startTestRun(t, d)
+	releaseID, err := d.SaveRelease(first, "1.22", date, "https://go.dev/doc/go1.22")
+	if err != nil {
+		t.Fatal(err)
+	}
+	changeID, err := d.InsertPackageChange(PackageChange{
+		ReleaseID:      releaseID,
+		Package:        "net/http",
+		ChangeType:     "Modified",
+		Description:    "The HTTP/2 server now limits the number of CONTINUATION frames (CVE-2023-45288).",
+		IngestionRunID: first,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	original := GodebugEvent{Name: "httpmuxgo121", Version: "1.22", Kind: "introduced", Value: "1", Package: "net/http", Description: "original"}
+	if err := d.SaveGodebugEvent(first, original); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.FinishIngestionRun(first, IngestionSucceeded); err != nil {
+		t.Fatal(err)
+	}
+
+	// 2 回目の取り込みでリリース・GODEBUG 設定のイベントを上書きし、既存の変更を Security Fix にする
+	second := startTestRun(t, d)
+	if _, err := d.SaveRelease(second, "1.22", date, "https://go.dev/doc/go1.22#library"); err != nil {
+		t.Fatal(err)
+	}
+	overwritten := original
+	overwritten.Description = "overwritten"
+	if err := d.SaveGodebugEvent(second, overwritten); err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = d.ImportVulnerabilities(second, []VulnerabilityImport{{
+		Vulnerability: Vulnerability{ID: "GO-2024-2687", Aliases: []string{"CVE-2023-45288"}},
+		Fixes: []VulnerabilityFix{{
+			ChangeID: changeID,
+			Change:   PackageChange{ChangeType: "Security Fix", SourceURL: "https://pkg.go.dev/vuln/GO-2024-2687"},
+		}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := d.FinishIngestionRun(second, IngestionSucceeded); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := d.RollbackIngestionRun(second, "alice")
+	if err != nil {
+		t.Fatalf("RollbackIngestionRun: %v", err)
+	}
+	if result.RestoredReleases != 1 || result.RestoredChanges != 1 || result.RestoredGodebugEvents != 1 {
+		t.Errorf("result = %+v, want 1 restored release, change and GODEBUG event", result)
+	}
+
+	releases, err := d.GetAllReleases()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(releases) != 1 {
+		t.Fatalf("got %d releases, want 1", len(releases))
+	}
+	if r := releases[0]; r.ID != releaseID || r.URL != "https://go.dev/doc/go1.22" || r.IngestionRunID != first {
+		t.Errorf("release = %+v, want id %d, the first URL and run %d", r, releaseID, first)
+	}
+
+	changes, err := d.GetPackageChanges(releaseID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var restored *PackageChange
+	for i := range changes {
+		if changes[i].ID == changeID {
+			restored = &changes[i]
+		}
+	}
+	if restored == nil {
+		t.Fatalf("change %d is not attached to release %d after rollback", changeID, releaseID)
+	}
+	if restored.ChangeType != "Modified" || restored.SourceURL != "" {
+		t.Errorf("change = %s (%q), want Modified without source URL", restored.ChangeType, restored.SourceURL)
+	}
+
+	vulns, err := d.GetChangeVulnerabilityIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vulns) != 0 {
+		t.Errorf("vulnerability links = %v, want none", vulns)
+	}
+
+	var description string
+	var runID int
+	err = d.db.QueryRow(`SELECT description, ingestion_run_id FROM godebug_events WHERE name = ? AND version = ?`,
+		original.Name, original.Version).Scan(&description, &runID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if description != "original" || runID != first {
+		t.Errorf("GODEBUG event = %q (run %d), want %q (run %d)", description, runID, "original", first)
+	}
+}
+
+// TestRollbackIngestionRunState は取り消し済み・実行中の実行記録を取り消せないことを確認する
+func TestRollbackIngestionRunState(t *testing.T) {
+	d := newTestDatabase(t)
+
+	done := startTestRun(t, d)
+	if err := d.FinishIngestionRun(done, IngestionSucceeded); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.RollbackIngestionRun(done, "alice"); err != nil {
+		t.Fatalf("first rollback: %v", err)
+	}
+	running := startTestRun(t, d)
+
+	tests := []struct {
+		name   string
+		runID  int
+		status string
+	}{
+		{name: "rolled back", runID: done, status: IngestionRolledBack},
+		{name: "running", runID: running, status: IngestionRunning},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := d.RollbackIngestionRun(tt.runID, "alice")
+			var stateErr *RunStateError
+			if !errors.As(err, &stateErr) {
+				t.Fatalf("err = %v, want *RunStateError", err)
+			}
+			if stateErr.Status != tt.status {
+				t.Errorf("status = %q, want %q", stateErr.Status, tt.status)
+			}
+		})
+	}
+
+	if _, err := d.RollbackIngestionRun(running+1, "alice"); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("unknown run: err = %v, want sql.ErrNoRows", err)
+	}
+}
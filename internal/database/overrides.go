@@ -0,0 +1,439 @@
+package database
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go-ver-trace/internal/classifier"
+)
+
+// ErrOverrideNotFound は指定した ID の上書き設定が存在しない場合のエラー
+var ErrOverrideNotFound = errors.New("override not found")
+
+// ChangeKey は変更の自然キー（再取得で ID が変わっても同じ変更を指す）
+type ChangeKey struct {
+	Version         string `json:"version"`
+	Area            string `json:"area"`
+	Package         string `json:"package"`
+	DescriptionHash string `json:"description_hash"`
+}
+
+// ChangeOverride は手作業による変更の補正（空の項目は補正しない）
+// 読み出し時に自然キーが一致する変更に適用するため、再取得で変更の行が作り直されても保持される
+type ChangeOverride struct {
+	ID         int       `json:"id"`
+	Key        ChangeKey `json:"key"`
+	ChangeType string    `json:"change_type,omitempty"`
+	Package    string    `json:"set_package,omitempty"`
+	SummaryJa  string    `json:"summary_ja,omitempty"`
+	Hidden     bool      `json:"hidden"`
+	Note       string    `json:"note,omitempty"`
+	UpdatedBy  string    `json:"updated_by"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	Matched    int       `json:"matched"` // 現在のデータで一致する変更の数（0 の場合は説明文が変わった可能性がある）
+}
+
+// OverrideAuditEntry は上書き設定の変更履歴
+type OverrideAuditEntry struct {
+	ID         int             `json:"id"`
+	OverrideID int             `json:"override_id"`
+	Action     string          `json:"action"` // create / update / delete
+	Actor      string          `json:"actor"`
+	Source     string          `json:"source"` // api / YAML ファイルのパス
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// 変更履歴の操作
+const (
+	OverrideCreated = "create"
+	OverrideUpdated = "update"
+	OverrideDeleted = "delete"
+)
+
+// DescriptionHash は自然キーに使う説明文のハッシュ（空白の違いは無視する）
+func DescriptionHash(description string) string {
+	sum := sha256.Sum256([]byte(strings.Join(strings.Fields(description), " ")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// KeyOf は version のリリースに含まれる変更の自然キーを返す
+func KeyOf(version string, c PackageChange) ChangeKey {
+	area := c.Area
+	if area == "" {
+		area = DefaultArea
+	}
+	return ChangeKey{Version: version, Area: area, Package: c.Package, DescriptionHash: DescriptionHash(c.Description)}
+}
+
+// migrateOverrides は上書き設定と変更履歴のテーブルを作成する
+func (d *Database) migrateOverrides() error {
+	queries := []string{
+		`CREATE TABLE IF NOT EXISTS change_overrides (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			version TEXT NOT NULL,
+			area TEXT NOT NULL,
+			package TEXT NOT NULL,
+			description_hash TEXT NOT NULL,
+			change_type TEXT NOT NULL DEFAULT '',
+			new_package TEXT NOT NULL DEFAULT '',
+			summary_ja TEXT NOT NULL DEFAULT '',
+			hidden INTEGER NOT NULL DEFAULT 0,
+			note TEXT NOT NULL DEFAULT '',
+			updated_by TEXT NOT NULL,
+			created_at DATETIME NOT NULL,
+			updated_at DATETIME NOT NULL,
+			UNIQUE (version, area, package, description_hash)
+		)`,
+		`CREATE TABLE IF NOT EXISTS change_override_audit (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			override_id INTEGER NOT NULL,
+			action TEXT NOT NULL,
+			actor TEXT NOT NULL,
+			source TEXT NOT NULL,
+			before_json TEXT,
+			after_json TEXT,
+			created_at DATETIME NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_change_override_audit_override_id ON change_override_audit (override_id)`,
+	}
+	for _, query := range queries {
+		if _, err := d.db.Exec(query); err != nil {
+			return fmt.Errorf("failed to migrate change overrides: %w", err)
+		}
+	}
+	return nil
+}
+
+const overrideColumns = `id, version, area, package, description_hash, change_type, new_package, summary_ja, hidden, note, updated_by, created_at, updated_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanOverride(row rowScanner) (ChangeOverride, error) {
+	var o ChangeOverride
+	err := row.Scan(&o.ID, &o.Key.Version, &o.Key.Area, &o.Key.Package, &o.Key.DescriptionHash,
+		&o.ChangeType, &o.Package, &o.SummaryJa, &o.Hidden, &o.Note, &o.UpdatedBy, &o.CreatedAt, &o.UpdatedAt)
+	return o, err
+}
+
+// loadOverrides は自然キーごとの上書き設定を返す
+func (d *Database) loadOverrides() (map[ChangeKey]ChangeOverride, error) {
+	rows, err := d.db.Query(`SELECT ` + overrideColumns + ` FROM change_overrides`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query change overrides: %w", err)
+	}
+	defer rows.Close()
+
+	overrides := make(map[ChangeKey]ChangeOverride)
+	for rows.Next() {
+		o, err := scanOverride(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan change override: %w", err)
+		}
+		overrides[o.Key] = o
+	}
+	return overrides, rows.Err()
+}
+
+// GetOverrides は上書き設定の一覧を、現在のデータで一致する変更の数とともに返す
+func (d *Database) GetOverrides() ([]ChangeOverride, error) {
+	overrides, err := d.loadOverrides()
+	if err != nil {
+		return nil, err
+	}
+	if len(overrides) > 0 {
+		changes, err := d.GetAllPackageChanges()
+		if err != nil {
+			return nil, err
+		}
+		versions, err := d.releaseVersions()
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range changes {
+			key := KeyOf(versions[c.ReleaseID], c)
+			if o, ok := overrides[key]; ok {
+				o.Matched++
+				overrides[key] = o
+			}
+		}
+	}
+
+	result := []ChangeOverride{}
+	for _, o := range overrides {
+		result = append(result, o)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+// GetChangeKey は変更 ID から自然キーを求める（API で変更 ID を指定して上書き設定を作成するため）
+func (d *Database) GetChangeKey(changeID int) (ChangeKey, error) {
+	var version string
+	var c PackageChange
+	err := d.db.QueryRow(`SELECT r.version, pc.package, COALESCE(pc.area, '`+DefaultArea+`'), COALESCE(pc.description, '')
+		FROM package_changes pc JOIN releases r ON pc.release_id = r.id WHERE pc.id = ?`, changeID).
+		Scan(&version, &c.Package, &c.Area, &c.Description)
+	if err != nil {
+		return ChangeKey{}, fmt.Errorf("failed to get change %d: %w", changeID, err)
+	}
+	return KeyOf(version, c), nil
+}
+
+// ValidateOverride は上書き設定の自然キーと補正内容を検証する
+func ValidateOverride(o ChangeOverride) error {
+	if o.Key.Version == "" || o.Key.Package == "" || o.Key.DescriptionHash == "" {
+		return fmt.Errorf("override key requires version, package and description_hash")
+	}
+	if o.ChangeType != "" && !classifier.IsType(o.ChangeType) {
+		return fmt.Errorf("unknown change type %q", o.ChangeType)
+	}
+	if o.ChangeType == "" && o.Package == "" && o.SummaryJa == "" && !o.Hidden {
+		return fmt.Errorf("override for %s %s sets nothing", o.Key.Version, o.Key.Package)
+	}
+	return nil
+}
+
+// SavedOverride は SaveOverrides で保存した上書き設定と操作（内容が同じ場合は空文字）
+type SavedOverride struct {
+	Override ChangeOverride
+	Action   string
+}
+
+// SaveOverride は上書き設定を自然キーで作成・更新し、変更履歴を記録する
+// 内容が同じ場合は何もせず、action は空文字を返す
+func (d *Database) SaveOverride(o ChangeOverride, actor, source string) (ChangeOverride, string, error) {
+	saved, err := d.SaveOverrides([]ChangeOverride{o}, actor, source)
+	if err != nil {
+		return ChangeOverride{}, "", err
+	}
+	return saved[0].Override, saved[0].Action, nil
+}
+
+// SaveOverrides は複数の上書き設定を 1 つのトランザクションで保存する（途中で失敗した場合は何も保存しない）
+func (d *Database) SaveOverrides(overrides []ChangeOverride, actor, source string) ([]SavedOverride, error) {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	saved := make([]SavedOverride, 0, len(overrides))
+	for _, o := range overrides {
+		result, action, err := saveOverride(tx, o, actor, source)
+		if err != nil {
+			return nil, fmt.Errorf("override for %s %s: %w", o.Key.Version, o.Key.Package, err)
+		}
+		saved = append(saved, SavedOverride{Override: result, Action: action})
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+func saveOverride(tx *sql.Tx, o ChangeOverride, actor, source string) (ChangeOverride, string, error) {
+	if o.Key.Area == "" {
+		o.Key.Area = DefaultArea
+	}
+	if err := ValidateOverride(o); err != nil {
+		return ChangeOverride{}, "", err
+	}
+
+	before, err := scanOverride(tx.QueryRow(`SELECT `+overrideColumns+` FROM change_overrides
+		WHERE version = ? AND area = ? AND package = ? AND description_hash = ?`,
+		o.Key.Version, o.Key.Area, o.Key.Package, o.Key.DescriptionHash))
+	exists := err == nil
+	if err != nil && err != sql.ErrNoRows {
+		return ChangeOverride{}, "", fmt.Errorf("failed to query change override: %w", err)
+	}
+	if exists && before.ChangeType == o.ChangeType && before.Package == o.Package &&
+		before.SummaryJa == o.SummaryJa && before.Hidden == o.Hidden && before.Note == o.Note {
+		return before, "", nil
+	}
+
+	now := time.Now().UTC()
+	action := OverrideUpdated
+	if exists {
+		_, err = tx.Exec(`UPDATE change_overrides SET change_type = ?, new_package = ?, summary_ja = ?, hidden = ?, note = ?, updated_by = ?, updated_at = ?
+			WHERE id = ?`, o.ChangeType, o.Package, o.SummaryJa, o.Hidden, o.Note, actor, now, before.ID)
+		o.ID, o.CreatedAt = before.ID, before.CreatedAt
+	} else {
+		action = OverrideCreated
+		var result sql.Result
+		result, err = tx.Exec(`INSERT INTO change_overrides (version, area, package, description_hash, change_type, new_package, summary_ja, hidden, note, updated_by, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			o.Key.Version, o.Key.Area, o.Key.Package, o.Key.DescriptionHash, o.ChangeType, o.Package, o.SummaryJa, o.Hidden, o.Note, actor, now, now)
+		if err == nil {
+			var id int64
+			id, err = result.LastInsertId()
+			o.ID, o.CreatedAt = int(id), now
+		}
+	}
+	if err != nil {
+		return ChangeOverride{}, "", fmt.Errorf("failed to save change override: %w", err)
+	}
+	o.UpdatedBy, o.UpdatedAt, o.Matched = actor, now, 0
+
+	var beforePtr *ChangeOverride
+	if exists {
+		beforePtr = &before
+	}
+	if err := recordOverrideAudit(tx, o.ID, action, actor, source, beforePtr, &o); err != nil {
+		return ChangeOverride{}, "", err
+	}
+	return o, action, nil
+}
+
+// DeleteOverride は上書き設定を削除し、変更履歴を記録する
+func (d *Database) DeleteOverride(id int, actor, source string) error {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	before, err := scanOverride(tx.QueryRow(`SELECT `+overrideColumns+` FROM change_overrides WHERE id = ?`, id))
+	if err == sql.ErrNoRows {
+		return ErrOverrideNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to query change override: %w", err)
+	}
+	if _, err := tx.Exec(`DELETE FROM change_overrides WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete change override %d: %w", id, err)
+	}
+	if err := recordOverrideAudit(tx, id, OverrideDeleted, actor, source, &before, nil); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func recordOverrideAudit(tx *sql.Tx, overrideID int, action, actor, source string, before, after *ChangeOverride) error {
+	encode := func(o *ChangeOverride) (interface{}, error) {
+		if o == nil {
+			return nil, nil
+		}
+		data, err := json.Marshal(o)
+		return string(data), err
+	}
+	beforeJSON, err := encode(before)
+	if err != nil {
+		return err
+	}
+	afterJSON, err := encode(after)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`INSERT INTO change_override_audit (override_id, action, actor, source, before_json, after_json, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		overrideID, action, actor, source, beforeJSON, afterJSON, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to record override audit: %w", err)
+	}
+	return nil
+}
+
+// GetOverrideAudit は上書き設定の変更履歴を新しい順に返す（overrideID が 0 の場合はすべて）
+func (d *Database) GetOverrideAudit(overrideID int) ([]OverrideAuditEntry, error) {
+	query := `SELECT id, override_id, action, actor, source, COALESCE(before_json, ''), COALESCE(after_json, ''), created_at
+			  FROM change_override_audit WHERE ? = 0 OR override_id = ? ORDER BY id DESC`
+	rows, err := d.db.Query(query, overrideID, overrideID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query override audit: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []OverrideAuditEntry{}
+	for rows.Next() {
+		var e OverrideAuditEntry
+		var before, after string
+		if err := rows.Scan(&e.ID, &e.OverrideID, &e.Action, &e.Actor, &e.Source, &before, &after, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan override audit: %w", err)
+		}
+		if before != "" {
+			e.Before = json.RawMessage(before)
+		}
+		if after != "" {
+			e.After = json.RawMessage(after)
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+// releaseVersions はリリース ID からバージョンへの対応を返す
+func (d *Database) releaseVersions() (map[int]string, error) {
+	rows, err := d.db.Query(`SELECT id, version FROM releases`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query release versions: %w", err)
+	}
+	defer rows.Close()
+
+	versions := make(map[int]string)
+	for rows.Next() {
+		var id int
+		var version string
+		if err := rows.Scan(&id, &version); err != nil {
+			return nil, fmt.Errorf("failed to scan release version: %w", err)
+		}
+		versions[id] = version
+	}
+	return versions, rows.Err()
+}
+
+// curate は変更に上書き設定を適用する（非表示の変更は除く）
+func (d *Database) curate(changes []PackageChange) ([]PackageChange, error) {
+	overrides, err := d.loadOverrides()
+	if err != nil || len(overrides) == 0 {
+		return changes, err
+	}
+	versions, err := d.releaseVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	curated := make([]PackageChange, 0, len(changes))
+	for _, c := range changes {
+		o, ok := overrides[KeyOf(versions[c.ReleaseID], c)]
+		if !ok {
+			curated = append(curated, c)
+			continue
+		}
+		if o.Hidden {
+			continue
+		}
+		if o.ChangeType != "" {
+			c.ChangeType = o.ChangeType
+			c.ChangeTypeConfidence = 1
+		}
+		if o.Package != "" {
+			c.Package = o.Package
+		}
+		if o.SummaryJa != "" {
+			c.Summary, c.SummaryLang, c.SummaryStatus = o.SummaryJa, DefaultLanguage, TranslationReviewed
+		}
+		c.OverrideID = o.ID
+		curated = append(curated, c)
+	}
+	return curated, nil
+}
+
+// GetCuratedPackageChanges は上書き設定を適用した全変更をリリース日順に返す
+func (d *Database) GetCuratedPackageChanges() ([]PackageChange, error) {
+	changes, err := d.GetAllPackageChanges()
+	if err != nil {
+		return nil, err
+	}
+	return d.curate(changes)
+}
@@ -0,0 +1,83 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"go-ver-trace/internal/platform"
+)
+
+// IncludesPlatforms は変更の対象プラットフォームが取得条件に含まれるか判定する
+// 対象プラットフォームを持たない変更は全プラットフォーム共通として常に含める
+func (o VisualizationOptions) IncludesPlatforms(platforms []platform.Platform) bool {
+	if len(o.Platforms) == 0 || len(platforms) == 0 {
+		return true
+	}
+	for _, p := range platforms {
+		for _, target := range o.Platforms {
+			if p.Matches(target) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// saveChangePlatforms は exec で変更の対象プラットフォームを保存する
+func saveChangePlatforms(exec execer, changeID int, platforms []platform.Platform) error {
+	for _, p := range platforms {
+		_, err := exec.Exec(`INSERT OR IGNORE INTO change_platforms (change_id, goos, goarch) VALUES (?, ?, ?)`,
+			changeID, p.GOOS, p.GOARCH)
+		if err != nil {
+			return fmt.Errorf("failed to save platform %s for change %d: %w", p, changeID, err)
+		}
+	}
+	return nil
+}
+
+// GetChangePlatforms は変更 ID ごとの対象プラットフォーム一覧を返す
+func (d *Database) GetChangePlatforms() (map[int][]platform.Platform, error) {
+	return d.queryChangePlatforms("", nil)
+}
+
+// GetChangePlatformsOf は指定した変更の対象プラットフォーム一覧を変更 ID ごとに返す
+func (d *Database) GetChangePlatformsOf(changeIDs []int) (map[int][]platform.Platform, error) {
+	if len(changeIDs) == 0 {
+		return map[int][]platform.Platform{}, nil
+	}
+	where, args := changeIDCondition(changeIDs)
+	return d.queryChangePlatforms(where, args)
+}
+
+func (d *Database) queryChangePlatforms(where string, args []interface{}) (map[int][]platform.Platform, error) {
+	rows, err := d.db.Query(`SELECT change_id, goos, goarch FROM change_platforms `+where+` ORDER BY change_id, goos, goarch`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query change platforms: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[int][]platform.Platform)
+	for rows.Next() {
+		var changeID int
+		var p platform.Platform
+		if err := rows.Scan(&changeID, &p.GOOS, &p.GOARCH); err != nil {
+			return nil, fmt.Errorf("failed to scan change platform: %w", err)
+		}
+		result[changeID] = append(result[changeID], p)
+	}
+
+	return result, rows.Err()
+}
+
+// FormatPlatforms はプラットフォーム一覧をカンマ区切りで返す
+func FormatPlatforms(platforms []platform.Platform) string {
+	return strings.Join(platformStrings(platforms), ",")
+}
+
+func platformStrings(platforms []platform.Platform) []string {
+	result := []string{}
+	for _, p := range platforms {
+		result = append(result, p.String())
+	}
+	return result
+}
@@ -0,0 +1,181 @@
+package database
+
+import (
+	"fmt"
+	"log"
+
+	"go-ver-trace/internal/goversion"
+)
+
+// ReconcileResult は正式リリースとプレリリースの変更の突き合わせ結果
+type ReconcileResult struct {
+	Releases []string `json:"releases"` // 突き合わせたプレリリース
+	Matched  int      `json:"matched"`  // 正式リリースの変更に対応付けた変更
+	Dropped  int      `json:"dropped"`  // 正式リリースに含まれなかった変更
+}
+
+// migratePrereleases はプレリリースのフラグと、プレリリースの変更から正式リリースの変更への参照を追加する
+func (d *Database) migratePrereleases() error {
+	if err := d.addColumnIfNotExists("releases", "prerelease", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		return fmt.Errorf("failed to migrate prerelease column: %w", err)
+	}
+	if err := d.addColumnIfNotExists("package_changes", "ga_change_id", "INTEGER REFERENCES package_changes (id)"); err != nil {
+		return fmt.Errorf("failed to migrate ga_change_id column: %w", err)
+	}
+	return nil
+}
+
+// ReconcilePrereleases は正式リリースが保存済みのマイナーバージョンについて、プレリリースの変更を正式リリースの変更に対応付ける
+// 領域・パッケージが同じ変更のうち、説明文が一致するものを優先し、残りは順に対応付ける（草稿から書き直された変更）
+// 対応付けられなかったプレリリースの変更は ga_change_id が NULL のまま残り、正式リリースまでに取り下げられたものとみなす
+func (d *Database) ReconcilePrereleases() (ReconcileResult, error) {
+	result := ReconcileResult{Releases: []string{}}
+
+	releases, err := d.GetAllReleases()
+	if err != nil {
+		return result, err
+	}
+	gaByBranch := make(map[string]Release)
+	for _, r := range releases {
+		if v, err := goversion.Parse(r.Version); err == nil && v.Pre == "" && v.Patch == 0 {
+			gaByBranch[v.Branch()] = r
+		}
+	}
+
+	for _, pre := range releases {
+		v, err := goversion.Parse(pre.Version)
+		if err != nil || v.Pre == "" {
+			continue
+		}
+		ga, ok := gaByBranch[v.Branch()]
+		if !ok {
+			continue
+		}
+
+		preChanges, err := d.GetPackageChanges(pre.ID)
+		if err != nil {
+			return result, err
+		}
+		gaChanges, err := d.GetPackageChanges(ga.ID)
+		if err != nil {
+			return result, err
+		}
+
+		matches := matchPrereleaseChanges(preChanges, gaChanges)
+		tx, err := d.db.Begin()
+		if err != nil {
+			return result, err
+		}
+		for _, c := range preChanges {
+			gaID, ok := matches[c.ID]
+			if ok {
+				result.Matched++
+			} else {
+				result.Dropped++
+			}
+			if _, err := tx.Exec(`UPDATE package_changes SET ga_change_id = NULLIF(?, 0) WHERE id = ?`, gaID, c.ID); err != nil {
+				tx.Rollback()
+				return result, fmt.Errorf("failed to reconcile change %d: %w", c.ID, err)
+			}
+		}
+		if err := tx.Commit(); err != nil {
+			return result, err
+		}
+
+		result.Releases = append(result.Releases, pre.Version)
+		log.Printf("Go %s の変更を Go %s と突き合わせました (%d 件)", pre.Version, ga.Version, len(matches))
+	}
+
+	return result, nil
+}
+
+// matchPrereleaseChanges はプレリリースの変更 ID から正式リリースの変更 ID への対応を返す
+func matchPrereleaseChanges(preChanges, gaChanges []PackageChange) map[int]int {
+	type key struct{ area, pkg string }
+	gaByKey := make(map[key][]PackageChange)
+	for _, c := range gaChanges {
+		k := key{c.Area, c.Package}
+		gaByKey[k] = append(gaByKey[k], c)
+	}
+	preByKey := make(map[key][]PackageChange)
+	var keys []key
+	for _, c := range preChanges {
+		k := key{c.Area, c.Package}
+		if _, ok := preByKey[k]; !ok {
+			keys = append(keys, k)
+		}
+		preByKey[k] = append(preByKey[k], c)
+	}
+
+	matches := make(map[int]int)
+	for _, k := range keys {
+		candidates := gaByKey[k]
+		used := make([]bool, len(candidates))
+
+		// 説明文が一致する変更を先に対応付ける
+		var rest []PackageChange
+		for _, pc := range preByKey[k] {
+			found := false
+			for i, gc := range candidates {
+				if !used[i] && gc.Description == pc.Description {
+					used[i] = true
+					matches[pc.ID] = gc.ID
+					found = true
+					break
+				}
+			}
+			if !found {
+				rest = append(rest, pc)
+			}
+		}
+
+		// 残りは出現順に対応付ける
+		i := 0
+		for _, pc := range rest {
+			for i < len(candidates) && used[i] {
+				i++
+			}
+			if i == len(candidates) {
+				break
+			}
+			used[i] = true
+			matches[pc.ID] = candidates[i].ID
+		}
+	}
+	return matches
+}
+
+// visibleReleaseIDs は可視化・差分の対象とするリリースの ID を返す
+// 正式リリースは常に対象とし、プレリリースは includeUpcoming の場合のみ、
+// 正式リリース前のマイナーバージョンごとに最新のものを対象とする（今後入る予定の変更）
+func visibleReleaseIDs(releases []Release, includeUpcoming bool) map[int]bool {
+	released := make(map[string]bool)
+	latestPre := make(map[string]Release)
+	for _, r := range releases {
+		v, err := goversion.Parse(r.Version)
+		if err != nil || v.Pre == "" {
+			if err == nil {
+				released[v.Branch()] = true
+			}
+			continue
+		}
+		if cur, ok := latestPre[v.Branch()]; !ok || goversion.Compare(r.Version, cur.Version) > 0 {
+			latestPre[v.Branch()] = r
+		}
+	}
+
+	visible := make(map[int]bool)
+	for _, r := range releases {
+		if !r.Prerelease {
+			visible[r.ID] = true
+		}
+	}
+	if includeUpcoming {
+		for branch, r := range latestPre {
+			if !released[branch] {
+				visible[r.ID] = true
+			}
+		}
+	}
+	return visible
+}
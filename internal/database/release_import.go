@@ -0,0 +1,85 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"go-ver-trace/internal/goversion"
+)
+
+// ReleaseImport はまとめて取り込むリリースとその変更
+type ReleaseImport struct {
+	Version     string
+	ReleaseDate time.Time // 保存済みのリリースの場合は使わない（保存済みでないリリースには必須）
+	URL         string
+	Changes     []PackageChange
+}
+
+// ReleaseImportResult はリリースごとの取り込み結果
+type ReleaseImportResult struct {
+	Version   string `json:"version"`
+	ReleaseID int    `json:"release_id"`
+	Created   bool   `json:"created"`  // リリースを新しく作成した
+	Imported  int    `json:"imported"` // 保存した変更の数
+	Skipped   int    `json:"skipped"`  // 同じリリース・パッケージ・説明文の変更が保存済みのため保存しなかった変更の数
+}
+
+// ImportReleases はリリースと変更を実行記録 runID に紐付けてまとめて保存する（途中で失敗した場合は何も保存しない）
+// 保存済みのリリースはリリース日・URL を変えずに変更だけを加え、同じパッケージ・説明文の変更は保存しない
+func (d *Database) ImportReleases(runID int, releases []ReleaseImport) ([]ReleaseImportResult, error) {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	results := make([]ReleaseImportResult, 0, len(releases))
+	for _, r := range releases {
+		result := ReleaseImportResult{Version: r.Version}
+		err := tx.QueryRow(`SELECT id FROM releases WHERE version = ?`, r.Version).Scan(&result.ReleaseID)
+		switch {
+		case err == sql.ErrNoRows:
+			if r.ReleaseDate.IsZero() {
+				return nil, fmt.Errorf("release date of Go %s is unknown", r.Version)
+			}
+			res, err := tx.Exec(`INSERT INTO releases (version, release_date, url, prerelease, ingestion_run_id) VALUES (?, ?, ?, ?, NULLIF(?, 0))`,
+				r.Version, r.ReleaseDate, r.URL, goversion.IsPrerelease(r.Version), runID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to save release %s: %w", r.Version, err)
+			}
+			id, err := res.LastInsertId()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get last insert id: %w", err)
+			}
+			result.ReleaseID, result.Created = int(id), true
+		case err != nil:
+			return nil, fmt.Errorf("failed to query release %s: %w", r.Version, err)
+		}
+
+		for _, c := range r.Changes {
+			var exists bool
+			err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM package_changes WHERE release_id = ? AND package = ? AND description = ?)`,
+				result.ReleaseID, c.Package, c.Description).Scan(&exists)
+			if err != nil {
+				return nil, fmt.Errorf("failed to query package change of %s in Go %s: %w", c.Package, r.Version, err)
+			}
+			if exists {
+				result.Skipped++
+				continue
+			}
+			c.ReleaseID = result.ReleaseID
+			c.IngestionRunID = runID
+			if _, err := d.insertPackageChange(tx, c); err != nil {
+				return nil, fmt.Errorf("%s in Go %s: %w", c.Package, r.Version, err)
+			}
+			result.Imported++
+		}
+		results = append(results, result)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
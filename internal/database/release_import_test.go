@@ -0,0 +1,35 @@
+package database
+
+import (
+	"testing"
+	"time"
+)
+
+// TestImportReleasesRollback は途中のリリースで失敗した場合に、それまでのリリース・変更も保存しないことを確認する
+func TestImportReleasesRollback(t *testing.T) {
+	d := newTestDatabase(t)
+	releases := []ReleaseImport{
+		{
+			Version:     "1.23.1",
+			ReleaseDate: time.Date(2024, 9, 5, 0, 0, 0, 0, time.UTC),
+			Changes:     []PackageChange{{Package: "encoding/gob", ChangeType: "Modified", Description: "Security fix: stack exhaustion in Decoder.Decode."}},
+		},
+		// リリース日のわからない保存済みでないリリース
+		{
+			Version: "1.23.99",
+			Changes: []PackageChange{{Package: "net/http", ChangeType: "Modified", Description: "Bug fix."}},
+		},
+	}
+
+	if _, err := d.ImportReleases(0, releases); err == nil {
+		t.Fatal("ImportReleases succeeded, want error for a release without release date")
+	}
+
+	var count int
+	if err := d.db.QueryRow(`SELECT (SELECT COUNT(*) FROM releases) + (SELECT COUNT(*) FROM package_changes)`).Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != 0 {
+		t.Errorf("%d releases and changes saved, want none", count)
+	}
+}
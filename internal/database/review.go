@@ -0,0 +1,108 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"go-ver-trace/internal/langtag"
+)
+
+// PendingTranslation は確認待ちの要約と、確認に必要な変更の情報
+type PendingTranslation struct {
+	ChangeTranslation
+	Version     string `json:"version"`
+	Package     string `json:"package"`
+	Area        string `json:"area"`
+	ChangeType  string `json:"change_type"`
+	Description string `json:"description"`
+}
+
+// GetPendingTranslations は確認状態が status（空の場合は machine）の要約を新しいリリース順に返す
+// lang が空の場合はすべての言語、limit が 0 以下の場合は件数を制限しない
+// 現在のリリースに含まれない変更の要約は含めない
+func (d *Database) GetPendingTranslations(lang, status string, limit int) ([]PendingTranslation, error) {
+	if status == "" {
+		status = TranslationMachine
+	}
+	if status != TranslationMachine && status != TranslationRejected {
+		return nil, fmt.Errorf("invalid status %q (machine or rejected)", status)
+	}
+	if lang != "" {
+		var err error
+		if lang, err = langtag.Normalize(lang); err != nil {
+			return nil, err
+		}
+	}
+	if limit <= 0 {
+		limit = -1
+	}
+
+	rows, err := d.db.Query(`SELECT t.change_id, t.lang, t.summary, t.status, t.updated_by, t.updated_at, t.reviewed_by, t.reviewed_at,
+			r.version, pc.package, COALESCE(pc.area, '`+DefaultArea+`'), pc.change_type, COALESCE(pc.description, '')
+		FROM change_translations t
+		JOIN package_changes pc ON pc.id = t.change_id
+		JOIN releases r ON r.id = pc.release_id
+		WHERE t.status = ? AND (? = '' OR t.lang = ?)
+		ORDER BY r.release_date DESC, pc.package, t.change_id, t.lang
+		LIMIT ?`, status, lang, lang, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending translations: %w", err)
+	}
+	defer rows.Close()
+
+	pending := []PendingTranslation{}
+	for rows.Next() {
+		var p PendingTranslation
+		var reviewedAt sql.NullTime
+		if err := rows.Scan(&p.ChangeID, &p.Lang, &p.Summary, &p.Status, &p.UpdatedBy, &p.UpdatedAt, &p.ReviewedBy, &reviewedAt,
+			&p.Version, &p.Package, &p.Area, &p.ChangeType, &p.Description); err != nil {
+			return nil, fmt.Errorf("failed to scan pending translation: %w", err)
+		}
+		if reviewedAt.Valid {
+			p.ReviewedAt = &reviewedAt.Time
+		}
+		pending = append(pending, p)
+	}
+	return pending, rows.Err()
+}
+
+// ReviewTranslation は変更の 1 言語分の要約を actor が確認した結果として保存する
+//   - status が reviewed で summary が空の場合: 今の要約をそのまま承認する
+//   - status が reviewed で summary がある場合: summary に修正して承認する
+//   - status が rejected の場合: 今の要約を却下する（却下した要約は表示しない）
+func (d *Database) ReviewTranslation(changeID int, lang, status, summary, actor string) (ChangeTranslation, error) {
+	if status == TranslationReviewed && summary != "" {
+		return d.SaveTranslation(ChangeTranslation{ChangeID: changeID, Lang: lang, Summary: summary}, actor)
+	}
+	if status != TranslationReviewed && status != TranslationRejected {
+		return ChangeTranslation{}, fmt.Errorf("invalid status %q (reviewed or rejected)", status)
+	}
+	if status == TranslationRejected && summary != "" {
+		return ChangeTranslation{}, fmt.Errorf("summary cannot be given when rejecting")
+	}
+	lang, err := langtag.Normalize(lang)
+	if err != nil {
+		return ChangeTranslation{}, err
+	}
+
+	result, err := d.db.Exec(`UPDATE change_translations SET status = ?, reviewed_by = ?, reviewed_at = ? WHERE change_id = ? AND lang = ?`,
+		status, actor, time.Now().UTC(), changeID, lang)
+	if err != nil {
+		return ChangeTranslation{}, fmt.Errorf("failed to review %s summary of change %d: %w", lang, changeID, err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ChangeTranslation{}, ErrTranslationNotFound
+	}
+
+	translations, err := d.GetTranslationsOfChange(changeID)
+	if err != nil {
+		return ChangeTranslation{}, err
+	}
+	for _, t := range translations {
+		if t.Lang == lang {
+			return t, nil
+		}
+	}
+	return ChangeTranslation{}, ErrTranslationNotFound
+}
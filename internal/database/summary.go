@@ -0,0 +1,67 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// ChangeSummary は変更の日本語要約の更新内容
+type ChangeSummary struct {
+	ID        int
+	SummaryJa string
+}
+
+// migrateSummaryCache は要約の作成方法・入力ごとに要約を保存するテーブルを作成する（description_hash は入力のハッシュ）
+func (d *Database) migrateSummaryCache() error {
+	_, err := d.db.Exec(`CREATE TABLE IF NOT EXISTS summary_cache (
+			summarizer TEXT NOT NULL,
+			description_hash TEXT NOT NULL,
+			summary TEXT NOT NULL,
+			created_at DATETIME NOT NULL,
+			PRIMARY KEY (summarizer, description_hash)
+		)`)
+	if err != nil {
+		return fmt.Errorf("failed to migrate summary cache: %w", err)
+	}
+	return nil
+}
+
+// GetCachedSummary は summarizer で作成済みの入力 key の要約を返す
+func (d *Database) GetCachedSummary(summarizer, key string) (string, bool, error) {
+	var summary string
+	err := d.db.QueryRow(`SELECT summary FROM summary_cache WHERE summarizer = ? AND description_hash = ?`,
+		summarizer, DescriptionHash(key)).Scan(&summary)
+	if err == sql.ErrNoRows {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to query summary cache: %w", err)
+	}
+	return summary, true, nil
+}
+
+// SaveCachedSummary は summarizer で作成した入力 key の要約を保存する
+func (d *Database) SaveCachedSummary(summarizer, key, summary string) error {
+	_, err := d.db.Exec(`INSERT OR REPLACE INTO summary_cache (summarizer, description_hash, summary, created_at) VALUES (?, ?, ?, ?)`,
+		summarizer, DescriptionHash(key), summary, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to save summary cache: %w", err)
+	}
+	return nil
+}
+
+// UpdateChangeSummaries は変更の日本語要約をまとめて更新する（途中で失敗した場合は何も更新しない）
+func (d *Database) UpdateChangeSummaries(updates []ChangeSummary) error {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return err
+	}
+	for _, u := range updates {
+		if err := saveTranslation(tx, ChangeTranslation{ChangeID: u.ID, Lang: DefaultLanguage, Summary: u.SummaryJa}); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to update summary of change %d: %w", u.ID, err)
+		}
+	}
+	return tx.Commit()
+}
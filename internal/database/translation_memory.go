@@ -0,0 +1,128 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// MemoryMinSimilarity は翻訳メモリで説明文をほぼ同じとみなす類似度の下限
+const MemoryMinSimilarity = 0.9
+
+// TranslationMemory は人が確認した（reviewed の）要約を説明文で引けるようにしたもの
+// 再取得で変更の行が作り直されても、同じ説明文・ほぼ同じ説明文の変更に以前の要約を使える
+type TranslationMemory struct {
+	lang    string
+	byHash  map[string]int // 説明文のハッシュから entries の位置
+	entries []memoryEntry
+}
+
+type memoryEntry struct {
+	words   []string
+	summary string
+}
+
+// LoadTranslationMemory は lang の確認済みの要約を読み込む（同じ説明文の要約が複数ある場合は新しいものを使う）
+func (d *Database) LoadTranslationMemory(lang string) (*TranslationMemory, error) {
+	rows, err := d.db.Query(`SELECT COALESCE(pc.description, ''), t.summary FROM change_translations t
+		JOIN package_changes pc ON pc.id = t.change_id
+		WHERE t.lang = ? AND t.status = ? ORDER BY t.updated_at, t.change_id`, lang, TranslationReviewed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query translation memory: %w", err)
+	}
+	defer rows.Close()
+
+	memory := &TranslationMemory{lang: lang, byHash: make(map[string]int)}
+	for rows.Next() {
+		var description, summary string
+		if err := rows.Scan(&description, &summary); err != nil {
+			return nil, fmt.Errorf("failed to scan translation memory: %w", err)
+		}
+		if description == "" {
+			continue
+		}
+		hash := DescriptionHash(description)
+		if i, ok := memory.byHash[hash]; ok {
+			memory.entries[i].summary = summary
+			continue
+		}
+		memory.byHash[hash] = len(memory.entries)
+		memory.entries = append(memory.entries, memoryEntry{words: words(description), summary: summary})
+	}
+	return memory, rows.Err()
+}
+
+// Lang は翻訳メモリの言語
+func (m *TranslationMemory) Lang() string { return m.lang }
+
+// Len は翻訳メモリの説明文の数
+func (m *TranslationMemory) Len() int { return len(m.entries) }
+
+// Lookup は説明文が同じ、またはほぼ同じ（類似度 MemoryMinSimilarity 以上）変更の要約を返す
+func (m *TranslationMemory) Lookup(description string) (summary string, similarity float64, ok bool) {
+	if i, ok := m.byHash[DescriptionHash(description)]; ok {
+		return m.entries[i].summary, 1, true
+	}
+
+	target := words(description)
+	for _, e := range m.entries {
+		if s := wordSimilarity(target, e.words); s >= MemoryMinSimilarity && s > similarity {
+			summary, similarity, ok = e.summary, s, true
+		}
+	}
+	return summary, similarity, ok
+}
+
+// words は説明文を小文字の単語に分ける（記号・空白の違いは無視する）
+func words(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// wordSimilarity は単語単位の編集距離による類似度（1 は同じ、0 はまったく異なる）
+func wordSimilarity(a, b []string) float64 {
+	longer := max(len(a), len(b))
+	if longer == 0 {
+		return 1
+	}
+	// 長さの差だけで下限を下回る場合は編集距離を計算しない
+	if float64(min(len(a), len(b)))/float64(longer) < MemoryMinSimilarity {
+		return 0
+	}
+
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return 1 - float64(prev[len(b)])/float64(longer)
+}
+
+// carryOverTranslations は説明文が同じ変更の確認済み・却下された要約を新しい変更 changeID に引き継ぐ（言語ごとに最新の要約）
+// 再取得で変更の行が作り直されても、翻訳者が書いた要約や確認の結果を失わないようにする
+func carryOverTranslations(exec execer, changeID int, description string) error {
+	if description == "" {
+		return nil
+	}
+	_, err := exec.Exec(`INSERT OR IGNORE INTO change_translations (change_id, lang, summary, status, updated_by, updated_at, reviewed_by, reviewed_at)
+		SELECT ?, t.lang, t.summary, t.status, t.updated_by, t.updated_at, t.reviewed_by, t.reviewed_at FROM change_translations t
+		JOIN package_changes pc ON pc.id = t.change_id
+		WHERE t.status != ? AND pc.description = ? AND pc.id != ?
+		ORDER BY t.reviewed_at DESC`, changeID, TranslationMachine, description, changeID)
+	if err != nil {
+		return fmt.Errorf("failed to carry over translations to change %d: %w", changeID, err)
+	}
+	return nil
+}
@@ -0,0 +1,366 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"go-ver-trace/internal/langtag"
+)
+
+// DefaultLanguage は要約の既定の言語（言語の指定がない要求・取り込み時の要約）
+const DefaultLanguage = "ja"
+
+// ErrChangeNotFound は指定された変更が存在しない場合のエラー
+var ErrChangeNotFound = errors.New("change not found")
+
+// ErrTranslationNotFound は指定された変更・言語の要約が存在しない場合のエラー
+var ErrTranslationNotFound = errors.New("translation not found")
+
+// 要約の確認状態
+const (
+	TranslationMachine  = "machine"  // 自動で作成した未確認の要約
+	TranslationReviewed = "reviewed" // 人が確認・修正した要約
+	TranslationRejected = "rejected" // 人が誤りとした要約（表示しない）
+)
+
+// ChangeTranslation は変更の要約の 1 言語分
+type ChangeTranslation struct {
+	ChangeID   int        `json:"change_id"`
+	Lang       string     `json:"lang"` // BCP-47 言語タグ
+	Summary    string     `json:"summary"`
+	Status     string     `json:"status"` // machine / reviewed / rejected
+	UpdatedBy  string     `json:"updated_by,omitempty"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	ReviewedBy string     `json:"reviewed_by,omitempty"`
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty"`
+}
+
+// changeTranslationColumns は change_translations の共通 SELECT 句
+const changeTranslationColumns = `change_id, lang, summary, status, updated_by, updated_at, reviewed_by, reviewed_at`
+
+// scanChangeTranslation は changeTranslationColumns で取得した行を読み込む
+func scanChangeTranslation(rows *sql.Rows) (ChangeTranslation, error) {
+	var t ChangeTranslation
+	var reviewedAt sql.NullTime
+	if err := rows.Scan(&t.ChangeID, &t.Lang, &t.Summary, &t.Status, &t.UpdatedBy, &t.UpdatedAt, &t.ReviewedBy, &reviewedAt); err != nil {
+		return ChangeTranslation{}, fmt.Errorf("failed to scan change translation: %w", err)
+	}
+	if reviewedAt.Valid {
+		t.ReviewedAt = &reviewedAt.Time
+	}
+	return t, nil
+}
+
+// migrateChangeTranslations は変更ごと・言語ごとの要約のテーブルを作成し、
+// package_changes.summary_ja に残っている要約を日本語（ja）の要約として移す
+func (d *Database) migrateChangeTranslations() error {
+	queries := []string{
+		`CREATE TABLE IF NOT EXISTS change_translations (
+			change_id INTEGER NOT NULL,
+			lang TEXT NOT NULL,
+			summary TEXT NOT NULL,
+			updated_by TEXT NOT NULL DEFAULT '',
+			updated_at DATETIME NOT NULL,
+			PRIMARY KEY (change_id, lang),
+			FOREIGN KEY (change_id) REFERENCES package_changes(id)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_change_translations_lang ON change_translations (lang)`,
+	}
+	for _, query := range queries {
+		if _, err := d.db.Exec(query); err != nil {
+			return fmt.Errorf("failed to migrate change translations: %w", err)
+		}
+	}
+	if err := d.migrateTranslationReview(); err != nil {
+		return err
+	}
+	return d.moveSummaryJa()
+}
+
+// migrateTranslationReview は要約の確認状態のカラムを追加し、人が書いた要約（updated_by のある要約）を確認済みにする
+func (d *Database) migrateTranslationReview() error {
+	columns := []struct{ name, definition string }{
+		{"status", "TEXT NOT NULL DEFAULT '" + TranslationMachine + "'"},
+		{"reviewed_by", "TEXT NOT NULL DEFAULT ''"},
+		{"reviewed_at", "DATETIME"},
+	}
+	for _, c := range columns {
+		if err := d.addColumnIfNotExists("change_translations", c.name, c.definition); err != nil {
+			return fmt.Errorf("failed to migrate change_translations.%s column: %w", c.name, err)
+		}
+	}
+	_, err := d.db.Exec(`UPDATE change_translations SET status = ?, reviewed_by = updated_by, reviewed_at = updated_at
+		WHERE status = ? AND updated_by != '' AND reviewed_at IS NULL`, TranslationReviewed, TranslationMachine)
+	if err != nil {
+		return fmt.Errorf("failed to mark human translations as reviewed: %w", err)
+	}
+	_, err = d.db.Exec(`CREATE INDEX IF NOT EXISTS idx_change_translations_status ON change_translations (status, lang)`)
+	if err != nil {
+		return fmt.Errorf("failed to migrate change translations: %w", err)
+	}
+	return nil
+}
+
+// moveSummaryJa は summary_ja カラムの要約を change_translations に移し、カラムを空にする
+// 既に日本語の要約がある変更は change_translations の要約を優先する
+func (d *Database) moveSummaryJa() error {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`INSERT OR IGNORE INTO change_translations (change_id, lang, summary, updated_at)
+		SELECT id, ?, summary_ja, COALESCE(created_at, CURRENT_TIMESTAMP) FROM package_changes
+		WHERE summary_ja IS NOT NULL AND summary_ja != ''`, DefaultLanguage)
+	if err != nil {
+		return fmt.Errorf("failed to move summary_ja to change_translations: %w", err)
+	}
+	if _, err := tx.Exec(`UPDATE package_changes SET summary_ja = NULL WHERE summary_ja IS NOT NULL`); err != nil {
+		return fmt.Errorf("failed to clear summary_ja: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	if moved, _ := result.RowsAffected(); moved > 0 {
+		log.Printf("summary_ja の要約 %d 件を change_translations に移しました", moved)
+	}
+	return nil
+}
+
+// execer は *sql.DB と *sql.Tx の共通部分
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// saveTranslation は変更の要約を保存する（空の要約は保存しない）
+// 確認状態が空・machine の要約は自動で作成した要約として保存し、確認済みの要約や
+// 同じ内容で却下された要約は上書きしない。reviewed の要約は UpdatedBy が確認したものとして保存する
+func saveTranslation(exec execer, t ChangeTranslation) error {
+	if t.Summary == "" {
+		return nil
+	}
+	if t.UpdatedAt.IsZero() {
+		t.UpdatedAt = time.Now().UTC()
+	}
+	var err error
+	if t.Status == TranslationReviewed {
+		_, err = exec.Exec(`INSERT OR REPLACE INTO change_translations (change_id, lang, summary, status, updated_by, updated_at, reviewed_by, reviewed_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			t.ChangeID, t.Lang, t.Summary, TranslationReviewed, t.UpdatedBy, t.UpdatedAt, t.UpdatedBy, t.UpdatedAt)
+	} else {
+		_, err = exec.Exec(`INSERT INTO change_translations (change_id, lang, summary, status, updated_by, updated_at) VALUES (?, ?, ?, ?, '', ?)
+			ON CONFLICT (change_id, lang) DO UPDATE SET summary = excluded.summary, status = excluded.status, updated_by = '',
+				updated_at = excluded.updated_at, reviewed_by = '', reviewed_at = NULL
+			WHERE change_translations.status != ? AND change_translations.summary != excluded.summary`,
+			t.ChangeID, t.Lang, t.Summary, TranslationMachine, t.UpdatedAt, TranslationReviewed)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save %s summary of change %d: %w", t.Lang, t.ChangeID, err)
+	}
+	return nil
+}
+
+// GetChangeTranslations は変更 ID ごと・言語ごとの要約を返す
+func (d *Database) GetChangeTranslations() (map[int]map[string]ChangeTranslation, error) {
+	return d.queryChangeTranslations("", nil)
+}
+
+// GetChangeTranslationsOf は指定した変更の要約を変更 ID ごと・言語ごとに返す
+func (d *Database) GetChangeTranslationsOf(changeIDs []int) (map[int]map[string]ChangeTranslation, error) {
+	if len(changeIDs) == 0 {
+		return map[int]map[string]ChangeTranslation{}, nil
+	}
+	where, args := changeIDCondition(changeIDs)
+	return d.queryChangeTranslations(where, args)
+}
+
+func (d *Database) queryChangeTranslations(where string, args []interface{}) (map[int]map[string]ChangeTranslation, error) {
+	rows, err := d.db.Query(`SELECT `+changeTranslationColumns+` FROM change_translations `+where, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query change translations: %w", err)
+	}
+	defer rows.Close()
+
+	translations := make(map[int]map[string]ChangeTranslation)
+	for rows.Next() {
+		t, err := scanChangeTranslation(rows)
+		if err != nil {
+			return nil, err
+		}
+		if translations[t.ChangeID] == nil {
+			translations[t.ChangeID] = make(map[string]ChangeTranslation)
+		}
+		translations[t.ChangeID][t.Lang] = t
+	}
+	return translations, rows.Err()
+}
+
+// GetTranslationsOfChange は変更のすべての言語の要約を言語順に返す
+func (d *Database) GetTranslationsOfChange(changeID int) ([]ChangeTranslation, error) {
+	rows, err := d.db.Query(`SELECT `+changeTranslationColumns+` FROM change_translations WHERE change_id = ? ORDER BY lang`, changeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query translations of change %d: %w", changeID, err)
+	}
+	defer rows.Close()
+
+	translations := []ChangeTranslation{}
+	for rows.Next() {
+		t, err := scanChangeTranslation(rows)
+		if err != nil {
+			return nil, err
+		}
+		translations = append(translations, t)
+	}
+	return translations, rows.Err()
+}
+
+// SaveTranslation は変更の要約を actor が確認・修正した要約として保存する（言語タグは正規化して保存する）
+func (d *Database) SaveTranslation(t ChangeTranslation, actor string) (ChangeTranslation, error) {
+	lang, err := langtag.Normalize(t.Lang)
+	if err != nil {
+		return ChangeTranslation{}, err
+	}
+	if t.Summary == "" {
+		return ChangeTranslation{}, fmt.Errorf("summary is required")
+	}
+	var exists bool
+	if err := d.db.QueryRow(`SELECT EXISTS (SELECT 1 FROM package_changes WHERE id = ?)`, t.ChangeID).Scan(&exists); err != nil {
+		return ChangeTranslation{}, fmt.Errorf("failed to query change %d: %w", t.ChangeID, err)
+	}
+	if !exists {
+		return ChangeTranslation{}, fmt.Errorf("change %d: %w", t.ChangeID, ErrChangeNotFound)
+	}
+
+	now := time.Now().UTC()
+	saved := ChangeTranslation{ChangeID: t.ChangeID, Lang: lang, Summary: t.Summary, Status: TranslationReviewed,
+		UpdatedBy: actor, UpdatedAt: now, ReviewedBy: actor, ReviewedAt: &now}
+	if err := saveTranslation(d.db, saved); err != nil {
+		return ChangeTranslation{}, err
+	}
+	return saved, nil
+}
+
+// DeleteTranslation は変更の 1 言語分の要約を削除する
+func (d *Database) DeleteTranslation(changeID int, lang string) error {
+	lang, err := langtag.Normalize(lang)
+	if err != nil {
+		return err
+	}
+	result, err := d.db.Exec(`DELETE FROM change_translations WHERE change_id = ? AND lang = ?`, changeID, lang)
+	if err != nil {
+		return fmt.Errorf("failed to delete %s summary of change %d: %w", lang, changeID, err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrTranslationNotFound
+	}
+	return nil
+}
+
+// Localized は希望順の言語 langs のうち最初に見つかった言語の要約・言語・確認状態を設定した変更を返す
+// translations は変更の言語ごとの要約（上書き設定などで c.Summary を変えた場合は c.Summary を優先する）
+// 却下された要約は使わない。どの言語の要約もない場合・英語を希望した場合は英語の説明文の抜粋にする
+// （langs が空の場合は既定の言語）
+func (c PackageChange) Localized(translations map[string]ChangeTranslation, langs []string) PackageChange {
+	if len(langs) == 0 {
+		langs = []string{DefaultLanguage}
+	}
+	available := map[string]ChangeTranslation{}
+	for l, t := range translations {
+		available[l] = t
+	}
+	if c.Summary != "" && c.SummaryLang != "" {
+		available[c.SummaryLang] = ChangeTranslation{Lang: c.SummaryLang, Summary: c.Summary, Status: c.SummaryStatus}
+	}
+	tags := []string{}
+	for l, t := range available {
+		if t.Summary != "" && t.Status != TranslationRejected {
+			tags = append(tags, l)
+		}
+	}
+	if _, ok := available[langtag.Source]; !ok {
+		tags = append(tags, langtag.Source)
+	}
+
+	c.Summary, c.SummaryLang, c.SummaryStatus = c.DescriptionAs(DescriptionFormatExcerpt), langtag.Source, ""
+	if lang, ok := langtag.Match(langs, tags); ok {
+		if t, ok := available[lang]; ok && t.Summary != "" && t.Status != TranslationRejected {
+			c.Summary, c.SummaryLang, c.SummaryStatus = t.Summary, lang, t.Status
+		}
+	}
+	return c
+}
+
+// SaveTranslations は変更の要約を actor が確認した要約としてまとめて保存する（途中で失敗した場合は何も保存しない）
+// 言語タグは正規化して保存し、内容が同じ確認済みの要約は更新しない（保存した件数を返す）
+func (d *Database) SaveTranslations(translations []ChangeTranslation, actor string) (int, error) {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	saved := 0
+	now := time.Now().UTC()
+	for _, t := range translations {
+		lang, err := langtag.Normalize(t.Lang)
+		if err != nil {
+			return 0, fmt.Errorf("change %d: %w", t.ChangeID, err)
+		}
+		var current string
+		err = tx.QueryRow(`SELECT summary FROM change_translations WHERE change_id = ? AND lang = ? AND status = ?`, t.ChangeID, lang, TranslationReviewed).Scan(&current)
+		if err == nil && current == t.Summary {
+			continue
+		}
+		if err != nil && err != sql.ErrNoRows {
+			return 0, fmt.Errorf("failed to query %s summary of change %d: %w", lang, t.ChangeID, err)
+		}
+		if err := saveTranslation(tx, ChangeTranslation{ChangeID: t.ChangeID, Lang: lang, Summary: t.Summary, Status: TranslationReviewed, UpdatedBy: actor, UpdatedAt: now}); err != nil {
+			return 0, err
+		}
+		saved++
+	}
+	return saved, tx.Commit()
+}
+
+// GetChangeKeys は現在のリリースに含まれる変更の ID ごとの自然キーを返す
+// 変更の ID は再取得で変わるため、ファイルでやり取りする要約は自然キーで変更を指す
+func (d *Database) GetChangeKeys() (map[int]ChangeKey, error) {
+	changes, err := d.GetAllPackageChanges()
+	if err != nil {
+		return nil, err
+	}
+	versions, err := d.releaseVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[int]ChangeKey, len(changes))
+	for _, c := range changes {
+		keys[c.ID] = KeyOf(versions[c.ReleaseID], c)
+	}
+	return keys, nil
+}
+
+// GetTranslationsIn は lang の要約を変更 ID ごとに返す
+func (d *Database) GetTranslationsIn(lang string) (map[int]ChangeTranslation, error) {
+	rows, err := d.db.Query(`SELECT `+changeTranslationColumns+` FROM change_translations WHERE lang = ?`, lang)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s translations: %w", lang, err)
+	}
+	defer rows.Close()
+
+	translations := make(map[int]ChangeTranslation)
+	for rows.Next() {
+		t, err := scanChangeTranslation(rows)
+		if err != nil {
+			return nil, err
+		}
+		translations[t.ChangeID] = t
+	}
+	return translations, rows.Err()
+}
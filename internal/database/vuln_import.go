@@ -0,0 +1,96 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+)
+
+// VulnerabilityImport はまとめて取り込む脆弱性エントリと、それを修正したリリースの変更
+type VulnerabilityImport struct {
+	Vulnerability Vulnerability
+	Fixes         []VulnerabilityFix
+}
+
+// VulnerabilityFix は脆弱性を修正したリリース・パッケージの変更
+type VulnerabilityFix struct {
+	ChangeID int           // 脆弱性に言及している保存済みの変更（0 の場合は Change を追加する）
+	Change   PackageChange // 追加する変更（ChangeID がある場合は ChangeType・SourceURL で補強する）
+}
+
+// ImportVulnerabilities は脆弱性エントリと修正の変更を実行記録 runID に紐付けてまとめて保存する（途中で失敗した場合は何も保存しない）
+// 保存済みの変更はセキュリティ修正として補強し、ない場合は変更を追加して、追加・補強した変更の数を返す
+func (d *Database) ImportVulnerabilities(runID int, imports []VulnerabilityImport) (added, enriched int, err error) {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return 0, 0, err
+	}
+	defer tx.Rollback()
+
+	for _, imp := range imports {
+		v := imp.Vulnerability
+		if err := saveVulnerability(tx, v); err != nil {
+			return 0, 0, err
+		}
+
+		for _, fix := range imp.Fixes {
+			changeID := fix.ChangeID
+			if changeID != 0 {
+				if err := d.markSecurityFix(tx, runID, changeID, fix.Change.ChangeType, fix.Change.SourceURL); err != nil {
+					return 0, 0, err
+				}
+				enriched++
+			} else {
+				c := fix.Change
+				c.IngestionRunID = runID
+				if changeID, err = d.insertPackageChange(tx, c); err != nil {
+					return 0, 0, fmt.Errorf("%s (%s): %w", v.ID, c.Package, err)
+				}
+				added++
+			}
+
+			if err := linkChangeVulnerability(tx, runID, changeID, v.ID); err != nil {
+				return 0, 0, err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, 0, err
+	}
+	return added, enriched, nil
+}
+
+// saveVulnerability は脆弱性エントリを保存（既存の場合は上書き）
+func saveVulnerability(exec execer, v Vulnerability) error {
+	query := `INSERT OR REPLACE INTO vulnerabilities (id, aliases, summary, details, modified) VALUES (?, ?, ?, ?, ?)`
+	_, err := exec.Exec(query, v.ID, strings.Join(v.Aliases, ","), v.Summary, v.Details, v.Modified)
+	if err != nil {
+		return fmt.Errorf("failed to save vulnerability %s: %w", v.ID, err)
+	}
+	return nil
+}
+
+// markSecurityFix は既存の変更をセキュリティ修正として補強する（source_url は未設定の場合のみ更新）
+// 脆弱性データベースとの対応による種別のため、確信度は 1 とする
+func (d *Database) markSecurityFix(exec execer, runID, changeID int, changeType, sourceURL string) error {
+	if err := d.snapshotChange(exec, runID, changeID); err != nil {
+		return err
+	}
+	query := `UPDATE package_changes
+			  SET change_type = ?, change_type_confidence = 1,
+			      source_url = CASE WHEN COALESCE(source_url, '') = '' THEN ? ELSE source_url END
+			  WHERE id = ?`
+	if _, err := exec.Exec(query, changeType, sourceURL, changeID); err != nil {
+		return fmt.Errorf("failed to mark change %d as security fix: %w", changeID, err)
+	}
+	return nil
+}
+
+// linkChangeVulnerability は変更と脆弱性エントリを関連付ける
+func linkChangeVulnerability(exec execer, runID, changeID int, vulnID string) error {
+	query := `INSERT OR IGNORE INTO package_change_vulnerabilities (change_id, vuln_id, ingestion_run_id) VALUES (?, ?, NULLIF(?, 0))`
+	if _, err := exec.Exec(query, changeID, vulnID, runID); err != nil {
+		return fmt.Errorf("failed to link change %d to %s: %w", changeID, vulnID, err)
+	}
+	return nil
+}
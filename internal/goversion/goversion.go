@@ -0,0 +1,126 @@
+// Package goversion はリリース表記（"1.21", "1.23.4", "go1.22", "go1.25rc1"）の解析と比較を行う
+package goversion
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Version は解析済みの Go バージョン
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+	// Pre はプレリリースの種別（"beta" / "rc"。正式リリースは空文字列）
+	Pre    string
+	PreNum int
+}
+
+// "1.25rc1" / "1.22beta2" の末尾
+var prereleaseRegex = regexp.MustCompile(`(beta|rc)(\d+)$`)
+
+// Parse はリリース表記を解析する（"go" プレフィックスは省略可）
+func Parse(s string) (Version, error) {
+	raw := s
+	s = strings.TrimPrefix(strings.TrimSpace(s), "go")
+
+	var pre string
+	var preNum int
+	if m := prereleaseRegex.FindStringSubmatch(s); m != nil {
+		pre = m[1]
+		preNum, _ = strconv.Atoi(m[2])
+		s = strings.TrimSuffix(s, m[0])
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) < 1 || len(parts) > 3 {
+		return Version{}, fmt.Errorf("invalid Go version: %q", raw)
+	}
+
+	var nums [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("invalid Go version: %q", raw)
+		}
+		nums[i] = n
+	}
+
+	v := Version{Major: nums[0], Minor: nums[1], Patch: nums[2], Pre: pre, PreNum: preNum}
+	if v.Pre != "" && v.Patch != 0 {
+		// プレリリースはマイナーバージョンの最初のリリースにのみある
+		return Version{}, fmt.Errorf("invalid Go version: %q", raw)
+	}
+	return v, nil
+}
+
+// IsPrerelease は表記がプレリリース（beta / rc）かどうかを判定する
+func IsPrerelease(s string) bool {
+	v, err := Parse(s)
+	return err == nil && v.Pre != ""
+}
+
+// Compare は a と b を比較し、a < b なら -1、a == b なら 0、a > b なら 1 を返す
+// 解析できない表記は文字列として比較する
+func Compare(a, b string) int {
+	va, errA := Parse(a)
+	vb, errB := Parse(b)
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+	return va.Compare(vb)
+}
+
+func (v Version) Compare(other Version) int {
+	switch {
+	case v.Major != other.Major:
+		return compareInt(v.Major, other.Major)
+	case v.Minor != other.Minor:
+		return compareInt(v.Minor, other.Minor)
+	case v.Patch != other.Patch:
+		return compareInt(v.Patch, other.Patch)
+	case v.Pre != other.Pre:
+		// beta < rc < 正式リリース
+		return compareInt(preRank(v.Pre), preRank(other.Pre))
+	default:
+		return compareInt(v.PreNum, other.PreNum)
+	}
+}
+
+func preRank(pre string) int {
+	switch pre {
+	case "beta":
+		return 0
+	case "rc":
+		return 1
+	}
+	return 2
+}
+
+// Branch はマイナーリリースの系列（"1.23.4" -> "1.23"）を返す
+func (v Version) Branch() string {
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+}
+
+// String はリリース表記を返す（パッチ 0 は省略）
+func (v Version) String() string {
+	if v.Pre != "" {
+		return fmt.Sprintf("%s%s%d", v.Branch(), v.Pre, v.PreNum)
+	}
+	if v.Patch == 0 {
+		return v.Branch()
+	}
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,189 @@
+package importer
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MinorRevisionSchema is the JSON Schema of minor revision files (served at /api/schemas/minor-revisions)
+//
+//go:embed minor_revisions.schema.json
+var MinorRevisionSchema []byte
+
+// minorRevisionSchema is the parsed MinorRevisionSchema
+var minorRevisionSchema *jsonSchema
+
+func init() {
+	s, err := parseJSONSchema(MinorRevisionSchema)
+	if err != nil {
+		panic(fmt.Sprintf("failed to load the minor revision schema: %v", err))
+	}
+	minorRevisionSchema = s
+}
+
+// jsonSchema is the subset of JSON Schema used by minor_revisions.schema.json
+// oneOf is only an annotation for editors; callers choose the definition to validate against
+type jsonSchema struct {
+	Ref                  string                 `json:"$ref"`
+	Description          string                 `json:"description"`
+	Type                 string                 `json:"type"`
+	Properties           map[string]*jsonSchema `json:"properties"`
+	Required             []string               `json:"required"`
+	AdditionalProperties *bool                  `json:"additionalProperties"`
+	Items                *jsonSchema            `json:"items"`
+	MinItems             int                    `json:"minItems"`
+	MinLength            int                    `json:"minLength"`
+	Enum                 []string               `json:"enum"`
+	Pattern              string                 `json:"pattern"`
+	Format               string                 `json:"format"`
+	OneOf                []*jsonSchema          `json:"oneOf"`
+	Defs                 map[string]*jsonSchema `json:"$defs"`
+
+	pattern *regexp.Regexp
+	root    *jsonSchema
+}
+
+func parseJSONSchema(data []byte) (*jsonSchema, error) {
+	var root jsonSchema
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	if err := root.compile(&root); err != nil {
+		return nil, err
+	}
+	return &root, nil
+}
+
+// compile resolves patterns and checks references of s and its subschemas
+func (s *jsonSchema) compile(root *jsonSchema) error {
+	s.root = root
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", s.Pattern, err)
+		}
+		s.pattern = re
+	}
+	if s.Ref != "" {
+		if _, err := root.definition(s.Ref); err != nil {
+			return err
+		}
+	}
+	subschemas := append([]*jsonSchema{s.Items}, s.OneOf...)
+	for _, sub := range s.Properties {
+		subschemas = append(subschemas, sub)
+	}
+	for _, sub := range s.Defs {
+		subschemas = append(subschemas, sub)
+	}
+	for _, sub := range subschemas {
+		if sub == nil {
+			continue
+		}
+		if err := sub.compile(root); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// definition returns the schema referenced by ref (only #/$defs/<name> is supported)
+func (s *jsonSchema) definition(ref string) (*jsonSchema, error) {
+	name, ok := strings.CutPrefix(ref, "#/$defs/")
+	if !ok || s.Defs[name] == nil {
+		return nil, fmt.Errorf("unknown schema reference %q", ref)
+	}
+	return s.Defs[name], nil
+}
+
+// validate checks a value decoded by encoding/json and appends the violations to problems
+func (s *jsonSchema) validate(path string, value interface{}, problems []Problem) []Problem {
+	if s.Ref != "" {
+		def, _ := s.root.definition(s.Ref)
+		return def.validate(path, value, problems)
+	}
+	add := func(path, format string, args ...interface{}) {
+		problems = append(problems, Problem{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	switch s.Type {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			add(path, "must be an object")
+			return problems
+		}
+		for _, name := range s.Required {
+			if _, ok := object[name]; !ok {
+				add(path+"."+name, "is required")
+			}
+		}
+		names := make([]string, 0, len(object))
+		for name := range object {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			property, ok := s.Properties[name]
+			if !ok {
+				if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+					add(path, "unknown field %q", name)
+				}
+				continue
+			}
+			problems = property.validate(path+"."+name, object[name], problems)
+		}
+	case "array":
+		array, ok := value.([]interface{})
+		if !ok {
+			add(path, "must be an array")
+			return problems
+		}
+		if len(array) < s.MinItems {
+			add(path, "must have at least %d item(s)", s.MinItems)
+		}
+		if s.Items != nil {
+			for i, item := range array {
+				problems = s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, problems)
+			}
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			add(path, "must be a string")
+			return problems
+		}
+		switch {
+		case utf8.RuneCountInString(str) < s.MinLength:
+			add(path, "must not be empty")
+		case len(s.Enum) > 0 && !slices.Contains(s.Enum, str):
+			add(path, "%q is not one of %s", str, strings.Join(s.Enum, ", "))
+		case s.pattern != nil && !s.pattern.MatchString(str):
+			add(path, "%q does not match pattern %q", str, s.Pattern)
+		case !validFormat(s.Format, str):
+			add(path, "%q has invalid %s format", str, s.Format)
+		}
+	}
+	return problems
+}
+
+// validFormat checks the formats used by the schema (date and uri)
+func validFormat(format, value string) bool {
+	switch format {
+	case "date":
+		_, err := time.Parse("2006-01-02", value)
+		return err == nil
+	case "uri":
+		u, err := url.Parse(value)
+		return err == nil && u.Scheme != "" && u.Host != ""
+	}
+	return true
+}
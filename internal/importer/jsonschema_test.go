@@ -0,0 +1,79 @@
+package importer
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// testSchema uses every keyword supported by jsonSchema
+const testSchema = `{
+  "type": "array",
+  "minItems": 1,
+  "items": { "$ref": "#/$defs/entry" },
+  "$defs": {
+    "entry": {
+      "type": "object",
+      "required": ["version", "kind"],
+      "additionalProperties": false,
+      "properties": {
+        "version": { "type": "string", "pattern": "^1\\.[0-9]+$" },
+        "kind": { "type": "string", "enum": ["fix", "feature"] },
+        "date": { "type": "string", "format": "date" },
+        "url": { "type": "string", "format": "uri" },
+        "note": { "type": "string", "minLength": 1 }
+      }
+    }
+  }
+}`
+
+func TestJSONSchemaValidate(t *testing.T) {
+	schema, err := parseJSONSchema([]byte(testSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		data string
+		want []string
+	}{
+		{name: "valid", data: `[{"version": "1.24", "kind": "fix", "date": "2025-04-01", "url": "https://go.dev/issue/1"}]`},
+		{name: "required", data: `[{"version": "1.24"}]`, want: []string{"[0].kind: is required"}},
+		{name: "enum", data: `[{"version": "1.24", "kind": "bug"}]`, want: []string{`[0].kind: "bug" is not one of fix, feature`}},
+		{name: "pattern", data: `[{"version": "go1.24", "kind": "fix"}]`, want: []string{`[0].version: "go1.24" does not match pattern "^1\\.[0-9]+$"`}},
+		{name: "date format", data: `[{"version": "1.24", "kind": "fix", "date": "2025-13-01"}]`, want: []string{`[0].date: "2025-13-01" has invalid date format`}},
+		{name: "uri format", data: `[{"version": "1.24", "kind": "fix", "url": "go.dev/issue/1"}]`, want: []string{`[0].url: "go.dev/issue/1" has invalid uri format`}},
+		{name: "min length", data: `[{"version": "1.24", "kind": "fix", "note": ""}]`, want: []string{"[0].note: must not be empty"}},
+		{name: "additional properties", data: `[{"version": "1.24", "kind": "fix", "links": []}]`, want: []string{`[0]: unknown field "links"`}},
+		{name: "type", data: `[{"version": 1.24, "kind": "fix"}, "1.25"]`, want: []string{"[0].version: must be a string", "[1]: must be an object"}},
+		{name: "min items", data: `[]`, want: []string{": must have at least 1 item(s)"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var value interface{}
+			if err := json.Unmarshal([]byte(tt.data), &value); err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, p := range schema.validate("", value, nil) {
+				got = append(got, p.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("problems = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseJSONSchemaErrors(t *testing.T) {
+	for _, data := range []string{
+		`{"type": "string", "pattern": "["}`,
+		`{"type": "array", "items": {"$ref": "#/$defs/missing"}}`,
+	} {
+		if _, err := parseJSONSchema([]byte(data)); err == nil {
+			t.Errorf("parseJSONSchema(%s) succeeded, want error", data)
+		}
+	}
+}
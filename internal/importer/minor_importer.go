@@ -1,178 +1,348 @@
 package importer
 
 import (
-	"database/sql"
 	"encoding/json"
 	"fmt"
-	"io"
-	"os"
+	"net/http"
+	"sort"
 	"strings"
 	"time"
+
+	"go-ver-trace/internal/classifier"
+	"go-ver-trace/internal/database"
+	"go-ver-trace/internal/goversion"
+	"go-ver-trace/internal/scraper"
 )
 
-// MinorVersionChange represents a minor version change from JSON
-type MinorVersionChange struct {
-	Version string   `json:"version"`
+// Format is the layout of a minor revision JSON file
+type Format string
+
+const (
+	// FormatFlat has one entry per change: [{"version", "package", "change", "links"}, ...]
+	FormatFlat Format = "flat"
+	// FormatGrouped has one entry per version: [{"version", "changes": [{"package", "change", "links"}, ...]}, ...]
+	FormatGrouped Format = "grouped"
+)
+
+// NoPackage is the package of entries recording that a version has no standard library changes
+const NoPackage = "(none)"
+
+// MinorChange is an entry of the flat format
+type MinorChange struct {
+	Version     string   `json:"version"`
+	ReleaseDate string   `json:"release_date,omitempty"` // YYYY-MM-DD, required for versions not yet known
+	Package     string   `json:"package"`
+	Change      string   `json:"change"`
+	Links       []string `json:"links,omitempty"`
+}
+
+// MinorRevisionData is an entry of the grouped format
+type MinorRevisionData struct {
+	Version     string       `json:"version"`
+	ReleaseDate string       `json:"release_date,omitempty"` // YYYY-MM-DD, required for versions not yet known
+	Changes     []ChangeData `json:"changes"`
+
+	path string // JSON path of the (first) entry of the version
+}
+
+// ChangeData is a change of a version in the grouped format
+type ChangeData struct {
 	Package string   `json:"package"`
 	Change  string   `json:"change"`
-	Links   []string `json:"links"`
+	Links   []string `json:"links,omitempty"`
+
+	path string // JSON path of the change
 }
 
-// ImportMinorVersions imports minor version changes from JSON file to database
-func ImportMinorVersions(db *sql.DB, jsonFilePath string) error {
-	// Read JSON file
-	file, err := os.Open(jsonFilePath)
-	if err != nil {
-		return fmt.Errorf("failed to open JSON file: %w", err)
-	}
-	defer file.Close()
+// Problem is a schema violation found in a minor revision file
+type Problem struct {
+	Path    string `json:"path"` // JSON path such as [3].changes[1].package
+	Message string `json:"message"`
+}
 
-	data, err := io.ReadAll(file)
-	if err != nil {
-		return fmt.Errorf("failed to read JSON file: %w", err)
+func (p Problem) String() string {
+	return p.Path + ": " + p.Message
+}
+
+// ValidationError lists every problem found in a minor revision file
+type ValidationError struct {
+	File     string
+	Problems []Problem
+}
+
+func (e *ValidationError) Error() string {
+	lines := make([]string, 0, len(e.Problems))
+	for _, p := range e.Problems {
+		lines = append(lines, "  "+p.String())
 	}
+	return fmt.Sprintf("%s: %d problem(s)\n%s", e.File, len(e.Problems), strings.Join(lines, "\n"))
+}
 
-	var changes []MinorVersionChange
-	if err := json.Unmarshal(data, &changes); err != nil {
-		return fmt.Errorf("failed to unmarshal JSON: %w", err)
+// MinorImportReport is the result of importing a minor revision file
+type MinorImportReport struct {
+	Format   Format          `json:"format"`
+	Versions []VersionReport `json:"versions"`
+	Warnings []Problem       `json:"warnings"` // problems of the file that do not prevent importing
+}
+
+// VersionReport counts the changes imported and skipped for a version
+// Skipped changes are NoPackage entries and changes already stored with the same package and description
+type VersionReport struct {
+	Version  string `json:"version"`
+	Created  bool   `json:"created"` // the release did not exist before the import
+	Imported int    `json:"imported"`
+	Skipped  int    `json:"skipped"`
+}
+
+// Imported is the number of changes imported for every version
+func (r MinorImportReport) Imported() int {
+	n := 0
+	for _, v := range r.Versions {
+		n += v.Imported
 	}
+	return n
+}
 
-	// Begin transaction
-	tx, err := db.Begin()
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
-	}
-	defer tx.Rollback()
-
-	// Track processed versions to avoid duplicates
-	processedVersions := make(map[string]bool)
-	
-	for _, change := range changes {
-		// Extract version number (remove "go" prefix)
-		version := strings.TrimPrefix(change.Version, "go")
-		
-		// Skip if we've already processed this version
-		if !processedVersions[version] {
-			// Insert release if not exists
-			err := insertReleaseIfNotExists(tx, version)
-			if err != nil {
-				return fmt.Errorf("failed to insert release %s: %w", version, err)
-			}
-			processedVersions[version] = true
-		}
+// Skipped is the number of changes skipped for every version
+func (r MinorImportReport) Skipped() int {
+	n := 0
+	for _, v := range r.Versions {
+		n += v.Skipped
+	}
+	return n
+}
 
-		// Get release ID
-		var releaseID int
-		err := tx.QueryRow("SELECT id FROM releases WHERE version = ?", version).Scan(&releaseID)
-		if err != nil {
-			return fmt.Errorf("failed to get release ID for version %s: %w", version, err)
-		}
+// MinorImporter imports minor revision JSON files in either the flat or the grouped format
+type MinorImporter struct {
+	db         *database.Database
+	classifier *classifier.Classifier
+	client     *http.Client // used by Validate to check links
+}
 
-		// Skip if package is "(none)" or empty
-		if change.Package == "(none)" || change.Package == "" {
-			continue
-		}
+func NewMinorImporter(db *database.Database) *MinorImporter {
+	return &MinorImporter{db: db, classifier: classifier.Default(), client: &http.Client{Timeout: 15 * time.Second}}
+}
 
-		// Determine change type based on description
-		changeType := determineChangeType(change.Change)
+// SetClassifier は変更種別の判定規則を設定する（既定は組み込みの規則）
+func (mi *MinorImporter) SetClassifier(c *classifier.Classifier) {
+	mi.classifier = c
+}
 
-		// Insert package change
-		sourceURL := ""
-		if len(change.Links) > 0 {
-			sourceURL = change.Links[0]
-		}
+// Import validates a minor revision file (without checking links) and imports every version in a single transaction,
+// tagging the rows with the ingestion run runID. Nothing is imported if the file has any error (reported as *ValidationError) or saving fails
+func (mi *MinorImporter) Import(runID int, path string) (MinorImportReport, error) {
+	validation, revisions, err := mi.validate(path, false)
+	if err != nil {
+		return MinorImportReport{}, err
+	}
+	if !validation.Valid() {
+		return MinorImportReport{}, &ValidationError{File: path, Problems: validation.Errors}
+	}
 
-		_, err = tx.Exec(`
-			INSERT INTO package_changes (release_id, package, change_type, description, source_url)
-			VALUES (?, ?, ?, ?, ?)
-		`, releaseID, change.Package, changeType, change.Change, sourceURL)
-		
-		if err != nil {
-			return fmt.Errorf("failed to insert package change for %s: %w", change.Package, err)
+	report := MinorImportReport{Format: validation.Format, Warnings: validation.Warnings}
+	releases := make([]database.ReleaseImport, 0, len(revisions))
+	for _, revision := range revisions {
+		version := strings.TrimPrefix(revision.Version, "go")
+		release := database.ReleaseImport{Version: version, ReleaseDate: releaseDate(version, revision.ReleaseDate), URL: minorReleaseURL(version)}
+		skipped := 0
+		for _, change := range revision.Changes {
+			if change.Package == NoPackage {
+				skipped++
+				continue
+			}
+			release.Changes = append(release.Changes, mi.packageChange(change))
 		}
+		releases = append(releases, release)
+		report.Versions = append(report.Versions, VersionReport{Version: version, Skipped: skipped})
 	}
 
-	// Commit transaction
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+	results, err := mi.db.ImportReleases(runID, releases)
+	if err != nil {
+		return MinorImportReport{}, err
 	}
+	for i, result := range results {
+		report.Versions[i].Created = result.Created
+		report.Versions[i].Imported = result.Imported
+		report.Versions[i].Skipped += result.Skipped
+	}
+	return report, nil
+}
 
-	fmt.Printf("Successfully imported %d minor version changes\n", len(changes))
-	return nil
+// packageChange classifies a change and generates its Japanese summary
+func (mi *MinorImporter) packageChange(change ChangeData) database.PackageChange {
+	result := mi.classifier.Classify(change.Change)
+
+	// The first link is the source URL; every link is kept in change_links
+	sourceURL := ""
+	if len(change.Links) > 0 {
+		sourceURL = change.Links[0]
+	}
+	return database.PackageChange{
+		Package:              change.Package,
+		ChangeType:           result.Type,
+		ChangeTypeConfidence: result.Confidence,
+		Description:          change.Change,
+		Summary:              classifier.SummaryJa(change.Change, result.Type),
+		SummaryLang:          database.DefaultLanguage,
+		SourceURL:            sourceURL,
+		Links:                classifyLinks(change.Links),
+		Platforms:            scraper.DetectPlatforms(change.Package, "", change.Change),
+	}
 }
 
-// insertReleaseIfNotExists inserts a release record if it doesn't exist
-func insertReleaseIfNotExists(tx *sql.Tx, version string) error {
-	// Check if release exists
-	var count int
-	err := tx.QueryRow("SELECT COUNT(*) FROM releases WHERE version = ?", version).Scan(&count)
-	if err != nil {
-		return err
+// ParseMinorRevisions detects the format of a minor revision file and validates every entry against MinorRevisionSchema
+// All problems are returned together as *ValidationError.
+// Entries are grouped by version (flat entries of the same version are merged) in version order.
+// A grouped entry with no changes only records the release.
+func ParseMinorRevisions(data []byte) ([]MinorRevisionData, Format, error) {
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, "", &ValidationError{Problems: []Problem{{Path: "$", Message: "is not valid JSON: " + strings.TrimPrefix(err.Error(), "json: ")}}}
+	}
+	entries, ok := document.([]interface{})
+	if !ok {
+		return nil, "", &ValidationError{Problems: []Problem{{Path: "$", Message: "must be an array of entries"}}}
+	}
+	if len(entries) == 0 {
+		return nil, "", &ValidationError{Problems: []Problem{{Path: "$", Message: "has no entries"}}}
 	}
 
-	if count > 0 {
-		return nil // Already exists
+	p := &minorParser{byVersion: make(map[string]*MinorRevisionData)}
+	for i, entry := range entries {
+		p.parseEntry(fmt.Sprintf("[%d]", i), entry)
+	}
+	if len(p.problems) > 0 {
+		return nil, p.format, &ValidationError{Problems: p.problems}
 	}
 
-	// Estimate release date based on version
-	releaseDate := estimateReleaseDate(version)
-	releaseURL := fmt.Sprintf("https://go.dev/doc/devel/release#go%s", version)
+	revisions := make([]MinorRevisionData, 0, len(p.byVersion))
+	for _, r := range p.byVersion {
+		revisions = append(revisions, *r)
+	}
+	sort.Slice(revisions, func(i, j int) bool { return goversion.Compare(revisions[i].Version, revisions[j].Version) < 0 })
+	return revisions, p.format, nil
+}
 
-	_, err = tx.Exec(`
-		INSERT INTO releases (version, release_date, url)
-		VALUES (?, ?, ?)
-	`, version, releaseDate, releaseURL)
+// minorParser collects the entries and problems of a minor revision file
+type minorParser struct {
+	format    Format
+	byVersion map[string]*MinorRevisionData
+	problems  []Problem
+}
 
-	return err
+func (p *minorParser) problem(path, format string, args ...interface{}) {
+	p.problems = append(p.problems, Problem{Path: path, Message: fmt.Sprintf(format, args...)})
 }
 
-// determineChangeType determines the change type from description
-func determineChangeType(description string) string {
-	desc := strings.ToLower(description)
-	
-	if strings.Contains(desc, "security fix") || strings.Contains(desc, "cve-") {
-		return "Security Fix"
+func (p *minorParser) parseEntry(path string, entry interface{}) {
+	fields, ok := entry.(map[string]interface{})
+	if !ok {
+		p.problem(path, "must be an object")
+		return
 	}
-	if strings.Contains(desc, "bug fix") || strings.Contains(desc, "bug fixes") {
-		return "Bug Fix"
+	format, definition := FormatFlat, "#/$defs/minorChange"
+	if _, ok := fields["changes"]; ok {
+		format, definition = FormatGrouped, "#/$defs/minorRevision"
 	}
-	if strings.Contains(desc, "test fix") {
-		return "Test Fix"
+	if p.format == "" {
+		p.format = format
+	} else if format != p.format {
+		p.problem(path, "is a %s entry in a %s file (formats cannot be mixed)", format, p.format)
+		return
 	}
-	if strings.Contains(desc, "deprecated") || strings.Contains(desc, "deprecat") {
-		return "Deprecated"
+	schema, _ := minorRevisionSchema.definition(definition)
+	if problems := schema.validate(path, entry, nil); len(problems) > 0 {
+		p.problems = append(p.problems, problems...)
+		return
 	}
-	if strings.Contains(desc, "removed") || strings.Contains(desc, "remove") {
-		return "Removed"
+
+	// The entry matches the schema, so decoding cannot fail
+	raw, _ := json.Marshal(entry)
+	var revision MinorRevisionData
+	if format == FormatGrouped {
+		json.Unmarshal(raw, &revision)
+		for i := range revision.Changes {
+			revision.Changes[i].path = fmt.Sprintf("%s.changes[%d]", path, i)
+		}
+	} else {
+		var change MinorChange
+		json.Unmarshal(raw, &change)
+		revision = MinorRevisionData{Version: change.Version, ReleaseDate: change.ReleaseDate,
+			Changes: []ChangeData{{Package: change.Package, Change: change.Change, Links: change.Links, path: path}}}
+	}
+	revision.path = path
+
+	version := strings.TrimPrefix(revision.Version, "go")
+	existing, ok := p.byVersion[version]
+	if !ok {
+		revision.Version = version
+		p.byVersion[version] = &revision
+		return
 	}
-	if strings.Contains(desc, "added") || strings.Contains(desc, "add") || strings.Contains(desc, "new") {
-		return "Added"
+	if revision.ReleaseDate != "" && existing.ReleaseDate != "" && revision.ReleaseDate != existing.ReleaseDate {
+		p.problem(path+".release_date", "%s conflicts with %s given for go%s", revision.ReleaseDate, existing.ReleaseDate, version)
+		return
 	}
-	
-	// Default to Modified for other changes
-	return "Modified"
+	if existing.ReleaseDate == "" {
+		existing.ReleaseDate = revision.ReleaseDate
+	}
+	existing.Changes = append(existing.Changes, revision.Changes...)
 }
 
-// estimateReleaseDate estimates release date for minor versions
-func estimateReleaseDate(version string) string {
-	// Parse version to get base and minor numbers
-	parts := strings.Split(version, ".")
-	if len(parts) < 3 {
-		return time.Now().Format("2006-01-02T15:04:05Z")
+// releaseDate returns the release date given in the file, or the known date of the minor revision
+// A zero time means the date is unknown; importing fails unless the release already exists
+func releaseDate(version, given string) time.Time {
+	if given == "" {
+		given = minorReleaseDates[version]
+	}
+	date, err := time.Parse("2006-01-02", given)
+	if err != nil {
+		return time.Time{}
 	}
+	return date
+}
 
-	// Base Go 1.23 was released on 2024-08-13
-	baseDate := time.Date(2024, 8, 13, 0, 0, 0, 0, time.UTC)
-	
-	// Estimate minor versions as roughly monthly releases
-	if parts[0] == "1" && parts[1] == "23" {
-		minorVersion := 0
-		fmt.Sscanf(parts[2], "%d", &minorVersion)
-		
-		// Add approximately 1 month per minor version
-		estimatedDate := baseDate.AddDate(0, minorVersion, 0)
-		return estimatedDate.Format("2006-01-02T15:04:05Z")
+// minorReleaseURL returns the release history section of the branch of a minor revision
+func minorReleaseURL(version string) string {
+	v, err := goversion.Parse(version)
+	if err != nil {
+		return "https://go.dev/doc/devel/release"
 	}
+	return "https://go.dev/doc/devel/release#go" + v.Branch() + ".minor"
+}
+
+// minorReleaseDates are the release dates of minor revisions published on go.dev
+// Files may give release_date for versions not listed here
+var minorReleaseDates = map[string]string{
+	"1.23.1":  "2024-09-05",
+	"1.23.2":  "2024-10-01",
+	"1.23.3":  "2024-11-06",
+	"1.23.4":  "2024-12-03",
+	"1.23.5":  "2025-01-07",
+	"1.23.6":  "2025-02-04",
+	"1.23.7":  "2025-03-04",
+	"1.23.8":  "2025-04-01",
+	"1.23.9":  "2025-05-06",
+	"1.23.10": "2025-06-03",
+	"1.23.11": "2025-07-01",
+	"1.23.12": "2025-08-05",
+	"1.24.1":  "2025-03-04",
+	"1.24.2":  "2025-04-01",
+	"1.24.3":  "2025-05-06",
+	"1.24.4":  "2025-06-05",
+	"1.24.5":  "2025-07-08",
+	"1.24.6":  "2025-08-06",
+}
 
-	return time.Now().Format("2006-01-02T15:04:05Z")
-}
\ No newline at end of file
+// classifyLinks は JSON の links 配列を種別付きのリンクに変換する
+func classifyLinks(urls []string) []database.ChangeLink {
+	var links []database.ChangeLink
+	for _, rawURL := range urls {
+		link, ok := scraper.NewChangeLink(rawURL, "")
+		if !ok {
+			continue
+		}
+		links = append(links, database.ChangeLink{URL: link.URL, Text: link.Text, Kind: link.Kind})
+	}
+	return links
+}
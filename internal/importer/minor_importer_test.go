@@ -0,0 +1,127 @@
+package importer
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeMinorFile は minor revision JSON をテスト用のファイルに書き出す
+func writeMinorFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "minor.json")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// go1.23-minor-stdlib.json の抜粋
+const minorFixture = `[
+  {
+    "version": "go1.23.1",
+    "package": "encoding/gob",
+    "change": "Security fix: stack exhaustion in Decoder.Decode (CVE-2024-34156).",
+    "links": ["https://go.dev/issue/69139"]
+  },
+  {
+    "version": "go1.23.1",
+    "package": "go/build/constraint",
+    "change": "Security fix: stack exhaustion in Parse (CVE-2024-34158)."
+  },
+  {
+    "version": "go1.23.2",
+    "package": "database/sql",
+    "change": "Bug fix: panic in database/sql.(*connRequestSet).deleteIndex."
+  }
+]`
+
+// TestMinorImportRejects は知らないバージョン・標準ライブラリ以外のパッケージを含むファイルを、
+// 問題のない項目も含めて何も取り込まずにエラーにすることを確認する
+func TestMinorImportRejects(t *testing.T) {
+	tests := []struct {
+		name     string
+		entry    string // minorFixture の後に加える項目
+		wantPath string
+	}{
+		{
+			name:     "unknown version",
+			entry:    `{"version": "go1.23.99", "package": "net/http", "change": "Bug fix: unknown release."}`,
+			wantPath: "[3].version",
+		},
+		{
+			name:     "module package",
+			entry:    `{"version": "go1.23.2", "package": "golang.org/x/net/http2", "change": "Bug fix: not in the standard library."}`,
+			wantPath: "[3].package",
+		},
+		{
+			name:     "missing package",
+			entry:    `{"version": "go1.23.2", "package": "net/htttp", "change": "Bug fix: misspelled package."}`,
+			wantPath: "[3].package",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.name == "missing package" && gorootSrc() == "" {
+				t.Skip("GOROOT is not available")
+			}
+			db := newTestDB(t)
+			path := writeMinorFile(t, minorFixture[:len(minorFixture)-1]+",\n  "+tt.entry+"\n]")
+
+			_, err := NewMinorImporter(db).Import(0, path)
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Import error = %v, want *ValidationError", err)
+			}
+			if len(verr.Problems) != 1 || verr.Problems[0].Path != tt.wantPath {
+				t.Errorf("problems = %v, want one at %s", verr.Problems, tt.wantPath)
+			}
+
+			releases, err := db.GetAllReleases()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(releases) != 0 {
+				t.Errorf("%d releases saved, want none", len(releases))
+			}
+		})
+	}
+}
+
+// TestMinorImportIdempotent は同じファイルを取り込み直しても変更を重複して保存しないことを確認する
+func TestMinorImportIdempotent(t *testing.T) {
+	db := newTestDB(t)
+	path := writeMinorFile(t, minorFixture)
+	mi := NewMinorImporter(db)
+
+	first, err := mi.Import(0, path)
+	if err != nil {
+		t.Fatalf("first import: %v", err)
+	}
+	if first.Imported() != 3 || first.Skipped() != 0 || !first.Versions[0].Created {
+		t.Errorf("first import = %+v, want 3 imported into new releases", first.Versions)
+	}
+
+	second, err := mi.Import(0, path)
+	if err != nil {
+		t.Fatalf("second import: %v", err)
+	}
+	if second.Imported() != 0 || second.Skipped() != 3 {
+		t.Errorf("second import = %+v, want 3 skipped", second.Versions)
+	}
+	for _, v := range second.Versions {
+		if v.Created {
+			t.Errorf("Go %s created again", v.Version)
+		}
+	}
+
+	changes, err := db.GetAllPackageChanges()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 3 {
+		t.Errorf("%d changes stored, want 3", len(changes))
+	}
+}
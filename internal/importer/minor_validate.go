@@ -0,0 +1,251 @@
+package importer
+
+import (
+	"fmt"
+	"go/build"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"go-ver-trace/internal/goversion"
+)
+
+// linkCheckWorkers is the number of links requested at the same time
+const linkCheckWorkers = 4
+
+// MinorValidation is the result of validating a minor revision file
+// Errors make the file invalid (importing it fails); warnings are reported but do not prevent importing
+type MinorValidation struct {
+	File         string    `json:"file"`
+	Format       Format    `json:"format,omitempty"`
+	Versions     int       `json:"versions"`
+	Changes      int       `json:"changes"`
+	Errors       []Problem `json:"errors"`
+	Warnings     []Problem `json:"warnings"`
+	LinksChecked int       `json:"links_checked"`
+	LinksSkipped string    `json:"links_skipped,omitempty"` // why links were not requested
+}
+
+// Valid reports whether the file can be imported
+func (v *MinorValidation) Valid() bool {
+	return len(v.Errors) == 0
+}
+
+func (v *MinorValidation) error(path, format string, args ...interface{}) {
+	v.Errors = append(v.Errors, Problem{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *MinorValidation) warning(path, format string, args ...interface{}) {
+	v.Warnings = append(v.Warnings, Problem{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// Validate checks a minor revision file against MinorRevisionSchema, then checks that
+// every version is a known release (or has release_date) and every package is in the standard library.
+// Links are requested only if checkLinks is set, and are skipped when none of them can be reached (offline).
+func (mi *MinorImporter) Validate(path string, checkLinks bool) (MinorValidation, error) {
+	v, _, err := mi.validate(path, checkLinks)
+	return v, err
+}
+
+// validate returns the validation and, if the file matches the schema, its revisions
+func (mi *MinorImporter) validate(path string, checkLinks bool) (MinorValidation, []MinorRevisionData, error) {
+	v := MinorValidation{File: path, Errors: []Problem{}, Warnings: []Problem{}}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return v, nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	revisions, format, err := ParseMinorRevisions(data)
+	v.Format = format
+	if verr, ok := err.(*ValidationError); ok {
+		v.Errors = verr.Problems
+		v.LinksSkipped = "the file does not match the schema"
+		return v, nil, nil
+	}
+	if err != nil {
+		return v, nil, err
+	}
+
+	v.Versions = len(revisions)
+	for _, r := range revisions {
+		v.Changes += len(r.Changes)
+	}
+	if err := mi.checkReleases(&v, revisions); err != nil {
+		return v, nil, err
+	}
+	if err := mi.checkPackages(&v, revisions); err != nil {
+		return v, nil, err
+	}
+	if checkLinks {
+		mi.checkLinks(&v, revisions)
+	} else {
+		v.LinksSkipped = "link checks are disabled"
+	}
+	return v, revisions, nil
+}
+
+// checkReleases reports versions whose release date cannot be determined and release dates that are ignored
+func (mi *MinorImporter) checkReleases(v *MinorValidation, revisions []MinorRevisionData) error {
+	releases, err := mi.db.GetAllReleases()
+	if err != nil {
+		return err
+	}
+	known := make(map[string]string, len(releases))
+	for _, r := range releases {
+		known[r.Version] = r.ReleaseDate.Format("2006-01-02")
+	}
+
+	for _, r := range revisions {
+		stored, ok := known[r.Version]
+		switch {
+		case ok && r.ReleaseDate != "" && r.ReleaseDate != stored:
+			v.warning(r.path+".release_date", "is ignored: Go %s is stored with release date %s", r.Version, stored)
+		case ok:
+		case r.ReleaseDate == "" && minorReleaseDates[r.Version] == "":
+			v.error(r.path+".version", "go%s is not a known release (give release_date for new versions)", r.Version)
+		default:
+			branch, err := goversion.Parse(r.Version)
+			if _, ok := known[branch.Branch()]; err == nil && len(known) > 0 && !ok {
+				v.warning(r.path+".version", "Go %s is not in the database (import the release notes of the branch for base entries)", branch.Branch())
+			}
+		}
+	}
+	return nil
+}
+
+// checkPackages reports packages outside the standard library, and packages neither in the database nor in GOROOT
+// If GOROOT cannot be read, packages not in the database are only warnings since they cannot be checked
+func (mi *MinorImporter) checkPackages(v *MinorValidation, revisions []MinorRevisionData) error {
+	packages, err := mi.db.GetUniquePackages()
+	if err != nil {
+		return err
+	}
+	known := make(map[string]bool, len(packages))
+	for _, p := range packages {
+		known[p] = true
+	}
+	src := gorootSrc()
+
+	for _, r := range revisions {
+		for _, c := range r.Changes {
+			if c.Package == NoPackage {
+				continue
+			}
+			first, _, _ := strings.Cut(c.Package, "/")
+			switch {
+			case strings.Contains(first, "."):
+				v.error(c.path+".package", "%q is not a standard library package", c.Package)
+			case known[c.Package]:
+			case src == "":
+				v.warning(c.path+".package", "%q is not in the database (GOROOT is not available to check it)", c.Package)
+			case !isDir(filepath.Join(src, filepath.FromSlash(c.Package))):
+				v.error(c.path+".package", "%q is neither in the database nor in GOROOT", c.Package)
+			}
+		}
+	}
+	return nil
+}
+
+// gorootSrc returns the source tree of the local Go installation, or "" if it cannot be read
+func gorootSrc() string {
+	if build.Default.GOROOT == "" {
+		return ""
+	}
+	src := filepath.Join(build.Default.GOROOT, "src")
+	if !isDir(src) {
+		return ""
+	}
+	return src
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// linkResult is the response to a link (err is set if no response was received)
+type linkResult struct {
+	status int
+	err    error
+}
+
+// checkLinks requests every link once and reports links that respond with an error status
+// Links that cannot be reached are warnings; if none can be reached, the check is skipped as offline
+func (mi *MinorImporter) checkLinks(v *MinorValidation, revisions []MinorRevisionData) {
+	paths := make(map[string][]string)
+	for _, r := range revisions {
+		for _, c := range r.Changes {
+			for i, link := range c.Links {
+				paths[link] = append(paths[link], fmt.Sprintf("%s.links[%d]", c.path, i))
+			}
+		}
+	}
+	if len(paths) == 0 {
+		return
+	}
+	links := make([]string, 0, len(paths))
+	for link := range paths {
+		links = append(links, link)
+	}
+	sort.Strings(links)
+
+	results := make([]linkResult, len(links))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < linkCheckWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = mi.requestLink(links[i])
+			}
+		}()
+	}
+	for i := range links {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	reached := false
+	for _, result := range results {
+		reached = reached || result.err == nil
+	}
+	if !reached {
+		v.LinksSkipped = fmt.Sprintf("none of %d link(s) could be reached (offline)", len(links))
+		return
+	}
+
+	v.LinksChecked = len(links)
+	for i, link := range links {
+		for _, path := range paths[link] {
+			switch result := results[i]; {
+			case result.err != nil:
+				v.warning(path, "%s could not be reached: %v", link, result.err)
+			case result.status >= 400:
+				v.error(path, "%s responded with %d %s", link, result.status, http.StatusText(result.status))
+			}
+		}
+	}
+}
+
+// requestLink sends HEAD, and GET if the server rejects HEAD
+func (mi *MinorImporter) requestLink(link string) linkResult {
+	resp, err := mi.client.Head(link)
+	if err != nil {
+		return linkResult{err: err}
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 400 {
+		return linkResult{status: resp.StatusCode}
+	}
+
+	resp, err = mi.client.Get(link)
+	if err != nil {
+		return linkResult{err: err}
+	}
+	resp.Body.Close()
+	return linkResult{status: resp.StatusCode}
+}
@@ -0,0 +1,282 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go-ver-trace/internal/classifier"
+	"go-ver-trace/internal/database"
+)
+
+// OSVEntry is a single record of the Go vulnerability database (golang.org/x/vulndb data/osv)
+type OSVEntry struct {
+	ID         string         `json:"id"`
+	Modified   string         `json:"modified"`
+	Aliases    []string       `json:"aliases"`
+	Summary    string         `json:"summary"`
+	Details    string         `json:"details"`
+	Affected   []OSVAffected  `json:"affected"`
+	References []OSVReference `json:"references"`
+}
+
+type OSVAffected struct {
+	Package struct {
+		Ecosystem string `json:"ecosystem"`
+		Name      string `json:"name"`
+	} `json:"package"`
+	Ranges            []OSVRange `json:"ranges"`
+	EcosystemSpecific struct {
+		Imports []struct {
+			Path    string   `json:"path"`
+			Symbols []string `json:"symbols"`
+		} `json:"imports"`
+	} `json:"ecosystem_specific"`
+}
+
+type OSVRange struct {
+	Type   string     `json:"type"`
+	Events []OSVEvent `json:"events"`
+}
+
+type OSVEvent struct {
+	Introduced string `json:"introduced,omitempty"`
+	Fixed      string `json:"fixed,omitempty"`
+}
+
+type OSVReference struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+// OSVImporter merges stdlib/toolchain entries of a local vulndb checkout into package_changes
+type OSVImporter struct {
+	db *database.Database
+}
+
+func NewOSVImporter(db *database.Database) *OSVImporter {
+	return &OSVImporter{db: db}
+}
+
+// ImportDirectory は指定ディレクトリ配下の OSV JSON ファイルをすべて実行記録 runID に紐付けて取り込む
+// 読み込めないファイルが 1 つでもあれば何も保存せず、すべてのファイルのエラーをまとめて返す
+func (oi *OSVImporter) ImportDirectory(runID int, dir string) error {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.HasSuffix(path, ".json") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to walk OSV directory: %w", err)
+	}
+
+	var entries []OSVEntry
+	var errs []error
+	for _, file := range files {
+		entry, err := readOSVEntry(file)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		// index ファイルなど OSV 以外の JSON は ID を持たない
+		if entry == nil || !strings.HasPrefix(entry.ID, "GO-") {
+			continue
+		}
+		entries = append(entries, *entry)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to read %d of %d OSV files: %w", len(errs), len(files), errors.Join(errs...))
+	}
+
+	var imports []database.VulnerabilityImport
+	for _, entry := range entries {
+		imp, err := oi.vulnerabilityImport(entry)
+		if err != nil {
+			return fmt.Errorf("%s: %w", entry.ID, err)
+		}
+		if imp != nil {
+			imports = append(imports, *imp)
+		}
+	}
+
+	imported, enriched, err := oi.db.ImportVulnerabilities(runID, imports)
+	if err != nil {
+		return err
+	}
+	log.Printf("OSVインポート完了: 追加 %d 件, 既存変更の補強 %d 件", imported, enriched)
+	return nil
+}
+
+func readOSVEntry(path string) (*OSVEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// 配列形式の index ファイルは対象外
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		return nil, nil
+	}
+
+	var entry OSVEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &entry, nil
+}
+
+// vulnerabilityImport は OSV エントリを保存する内容に変換する（stdlib/toolchain の修正がない場合は nil）
+// 修正したリリースが未登録の組はスキップする
+func (oi *OSVImporter) vulnerabilityImport(entry OSVEntry) (*database.VulnerabilityImport, error) {
+	targets := stdlibFixTargets(entry)
+	if len(targets) == 0 {
+		return nil, nil
+	}
+
+	imp := &database.VulnerabilityImport{
+		Vulnerability: database.Vulnerability{
+			ID:       entry.ID,
+			Aliases:  entry.Aliases,
+			Summary:  entry.Summary,
+			Details:  entry.Details,
+			Modified: entry.Modified,
+		},
+	}
+	for _, target := range targets {
+		releaseID, err := oi.db.GetReleaseID(target.version)
+		if err != nil {
+			log.Printf("%s: Go %s のリリースが未登録のためスキップ (%s)", entry.ID, target.version, target.pkg)
+			continue
+		}
+
+		changes, err := oi.db.GetPackageChanges(releaseID)
+		if err != nil {
+			return nil, err
+		}
+
+		imp.Fixes = append(imp.Fixes, database.VulnerabilityFix{
+			ChangeID: findMatchingChange(changes, target.pkg, entry),
+			Change: database.PackageChange{
+				ReleaseID:            releaseID,
+				Package:              target.pkg,
+				ChangeType:           classifier.SecurityFix,
+				ChangeTypeConfidence: 1,
+				Description:          osvDescription(entry),
+				Summary:              classifier.SummaryJa(osvDescription(entry), classifier.SecurityFix),
+				SummaryLang:          database.DefaultLanguage,
+				SourceURL:            osvURL(entry.ID),
+			},
+		})
+	}
+	return imp, nil
+}
+
+// findMatchingChange は同じパッケージで脆弱性 ID または別名（CVE）に言及している変更の ID を返す（ない場合は 0）
+func findMatchingChange(changes []database.PackageChange, pkg string, entry OSVEntry) int {
+	ids := append([]string{entry.ID}, entry.Aliases...)
+	for _, change := range changes {
+		if change.Package != pkg {
+			continue
+		}
+		description := strings.ToUpper(change.Description + " " + change.SourceURL)
+		for _, id := range ids {
+			if strings.Contains(description, strings.ToUpper(id)) {
+				return change.ID
+			}
+		}
+	}
+	return 0
+}
+
+type osvFixTarget struct {
+	version string
+	pkg     string
+}
+
+// stdlibFixTargets は stdlib/toolchain モジュールの修正バージョンとパッケージの組を列挙する
+func stdlibFixTargets(entry OSVEntry) []osvFixTarget {
+	var targets []osvFixTarget
+	seen := make(map[osvFixTarget]bool)
+
+	for _, affected := range entry.Affected {
+		module := affected.Package.Name
+		if module != "stdlib" && module != "toolchain" {
+			continue
+		}
+
+		var packages []string
+		for _, imp := range affected.EcosystemSpecific.Imports {
+			if imp.Path != "" {
+				packages = append(packages, imp.Path)
+			}
+		}
+		if len(packages) == 0 && module == "toolchain" {
+			packages = []string{"cmd/go"}
+		}
+
+		for _, r := range affected.Ranges {
+			if r.Type != "SEMVER" {
+				continue
+			}
+			for _, event := range r.Events {
+				if event.Fixed == "" {
+					continue
+				}
+				version := osvVersionToRelease(event.Fixed)
+				for _, pkg := range packages {
+					target := osvFixTarget{version: version, pkg: pkg}
+					if !seen[target] {
+						seen[target] = true
+						targets = append(targets, target)
+					}
+				}
+			}
+		}
+	}
+
+	return targets
+}
+
+// osvVersionToRelease は OSV の SEMVER 表記をリリース表記に変換する
+// "1.22.7" -> "1.22.7", "1.22.0" -> "1.22", "1.21.0-0" -> "1.21"
+func osvVersionToRelease(version string) string {
+	version = strings.TrimPrefix(version, "v")
+	if i := strings.Index(version, "-"); i >= 0 {
+		version = version[:i]
+	}
+	parts := strings.Split(version, ".")
+	if len(parts) == 3 && parts[2] == "0" {
+		return parts[0] + "." + parts[1]
+	}
+	return version
+}
+
+func osvDescription(entry OSVEntry) string {
+	description := "Security fix"
+	if entry.Summary != "" {
+		description += ": " + strings.TrimSuffix(entry.Summary, ".")
+	}
+
+	ids := []string{entry.ID}
+	for _, alias := range entry.Aliases {
+		if strings.HasPrefix(alias, "CVE-") {
+			ids = append(ids, alias)
+		}
+	}
+	return fmt.Sprintf("%s (%s).", description, strings.Join(ids, ", "))
+}
+
+func osvURL(id string) string {
+	return "https://pkg.go.dev/vuln/" + id
+}
@@ -0,0 +1,198 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go-ver-trace/internal/classifier"
+	"go-ver-trace/internal/database"
+)
+
+func TestOSVVersionToRelease(t *testing.T) {
+	for version, want := range map[string]string{
+		"1.22.7":   "1.22.7",
+		"v1.22.7":  "1.22.7",
+		"1.22.0":   "1.22",
+		"1.21.0-0": "1.21",
+		"1.20":     "1.20",
+	} {
+		if got := osvVersionToRelease(version); got != want {
+			t.Errorf("osvVersionToRelease(%q) = %q, want %q", version, got, want)
+		}
+	}
+}
+
+func TestStdlibFixTargets(t *testing.T) {
+	toolchain := OSVAffected{Ranges: []OSVRange{{Type: "SEMVER", Events: []OSVEvent{{Introduced: "0"}, {Fixed: "1.20.5"}}}}}
+	toolchain.Package.Name = "toolchain"
+	ecosystem := OSVAffected{Ranges: []OSVRange{{Type: "ECOSYSTEM", Events: []OSVEvent{{Fixed: "1.20.5"}}}}}
+	ecosystem.Package.Name = "stdlib"
+
+	tests := []struct {
+		name  string
+		entry OSVEntry
+		want  []osvFixTarget
+	}{
+		{
+			name:  "stdlib entry, x/net module is ignored",
+			entry: loadOSVFixture(t, "ID/GO-2024-2687.json"),
+			want:  []osvFixTarget{{version: "1.21.9", pkg: "net/http"}, {version: "1.22.2", pkg: "net/http"}},
+		},
+		{
+			name:  "toolchain without imports is cmd/go",
+			entry: OSVEntry{ID: "GO-0000-0001", Affected: []OSVAffected{toolchain}},
+			want:  []osvFixTarget{{version: "1.20.5", pkg: "cmd/go"}},
+		},
+		{
+			name:  "non-SEMVER ranges are ignored",
+			entry: OSVEntry{ID: "GO-0000-0002", Affected: []OSVAffected{ecosystem}},
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stdlibFixTargets(tt.entry); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("stdlibFixTargets = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindMatchingChange(t *testing.T) {
+	entry := loadOSVFixture(t, "ID/GO-2024-2687.json")
+	tests := []struct {
+		name    string
+		changes []database.PackageChange
+		want    int
+	}{
+		{
+			name:    "CVE alias in the description",
+			changes: []database.PackageChange{{ID: 1, Package: "net/http", Description: "Fixes CVE-2023-45288 in the HTTP/2 server."}},
+			want:    1,
+		},
+		{
+			name:    "vulndb ID in the source URL, case-insensitive",
+			changes: []database.PackageChange{{ID: 2, Package: "net/http", SourceURL: "https://pkg.go.dev/vuln/go-2024-2687"}},
+			want:    2,
+		},
+		{
+			name:    "other package",
+			changes: []database.PackageChange{{ID: 3, Package: "net/url", Description: "CVE-2023-45288"}},
+			want:    0,
+		},
+		{
+			name:    "no mention",
+			changes: []database.PackageChange{{ID: 4, Package: "net/http", Description: "The new ServeMux patterns."}},
+			want:    0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := findMatchingChange(tt.changes, "net/http", entry); got != tt.want {
+				t.Errorf("findMatchingChange = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOSVImportDirectory(t *testing.T) {
+	db := newTestDB(t)
+	releaseID := saveTestRelease(t, db, "1.22.2")
+	saveTestRelease(t, db, "1.21.9")
+	existing, err := db.InsertPackageChange(database.PackageChange{
+		ReleaseID: releaseID, Package: "net/http", ChangeType: classifier.BugFix,
+		Description: "Fixes CVE-2023-45288 in the HTTP/2 server.",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewOSVImporter(db).ImportDirectory(0, filepath.Join("testdata", "osv")); err != nil {
+		t.Fatal(err)
+	}
+
+	vulns, err := db.GetChangeVulnerabilityIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vulns) != 2 {
+		t.Fatalf("%d changes linked to vulnerabilities, want 2 (one enriched, one added): %v", len(vulns), vulns)
+	}
+	if !reflect.DeepEqual(vulns[existing], []string{"GO-2024-2687"}) {
+		t.Errorf("existing change linked to %v, want GO-2024-2687", vulns[existing])
+	}
+	changes, err := db.GetPackageChanges(releaseID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || changes[0].ChangeType != classifier.SecurityFix {
+		t.Errorf("changes of Go 1.22.2 = %+v, want the existing change marked as %s", changes, classifier.SecurityFix)
+	}
+}
+
+// TestOSVImportDirectoryCorruptFile は読み込めないファイルがあれば何も保存せずエラーを返すことを確認する
+func TestOSVImportDirectoryCorruptFile(t *testing.T) {
+	db := newTestDB(t)
+	saveTestRelease(t, db, "1.22.2")
+	saveTestRelease(t, db, "1.21.9")
+
+	dir := t.TempDir()
+	data, err := os.ReadFile(filepath.Join("testdata", "osv", "ID", "GO-2024-2687.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{
+		"GO-2024-2687.json": string(data),
+		"GO-2024-9999.json": `{"id": "GO-2024-9999", "affected": [`,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = NewOSVImporter(db).ImportDirectory(0, dir)
+	if err == nil || !strings.Contains(err.Error(), "GO-2024-9999.json") {
+		t.Fatalf("ImportDirectory error = %v, want an error naming the corrupt file", err)
+	}
+	vulns, err := db.GetChangeVulnerabilityIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vulns) != 0 {
+		t.Errorf("%d changes linked after a failed import, want 0", len(vulns))
+	}
+}
+
+func loadOSVFixture(t *testing.T, name string) OSVEntry {
+	t.Helper()
+	entry, err := readOSVEntry(filepath.Join("testdata", "osv", filepath.FromSlash(name)))
+	if err != nil || entry == nil {
+		t.Fatalf("failed to load %s: %v", name, err)
+	}
+	return *entry
+}
+
+func newTestDB(t *testing.T) *database.Database {
+	t.Helper()
+	db, err := database.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func saveTestRelease(t *testing.T, db *database.Database, version string) int {
+	t.Helper()
+	id, err := db.SaveRelease(0, version, time.Date(2024, 4, 3, 0, 0, 0, 0, time.UTC), "https://go.dev/doc/devel/release#go"+version)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
@@ -0,0 +1,126 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"go-ver-trace/internal/database"
+)
+
+// OverridesFile is the YAML file of manual curation overrides
+//
+//	overrides:
+//	  - version: "1.22"
+//	    area: stdlib
+//	    package: net/http
+//	    description: "The new Request.PathValue method ..."  # or description_hash
+//	    change_type: Added
+//	    summary_ja: "..."
+type OverridesFile struct {
+	Overrides []OverrideEntry `yaml:"overrides"`
+}
+
+// OverrideEntry is one override keyed by the change's natural key
+// The description may be given as the full text (hashed on import) or as description_hash
+type OverrideEntry struct {
+	Version         string `yaml:"version"`
+	Area            string `yaml:"area"`
+	Package         string `yaml:"package"`
+	Description     string `yaml:"description"`
+	DescriptionHash string `yaml:"description_hash"`
+
+	ChangeType string `yaml:"change_type"`
+	SetPackage string `yaml:"set_package"`
+	SummaryJa  string `yaml:"summary_ja"`
+	Hidden     bool   `yaml:"hidden"`
+	Note       string `yaml:"note"`
+}
+
+// OverridesImportResult counts the overrides created, updated and left unchanged
+type OverridesImportResult struct {
+	Created   int
+	Updated   int
+	Unchanged int
+}
+
+// ImportOverrides reads a YAML overrides file and saves every entry as actor in a single transaction
+// All entries are validated before anything is saved, and unknown keys are rejected
+func ImportOverrides(db *database.Database, path, actor string) (OverridesImportResult, error) {
+	var result OverridesImportResult
+
+	f, err := os.Open(path)
+	if err != nil {
+		return result, fmt.Errorf("failed to read overrides file: %w", err)
+	}
+	defer f.Close()
+
+	// 綴りを誤ったキーを無視せずエラーにする
+	decoder := yaml.NewDecoder(f)
+	decoder.KnownFields(true)
+	var file OverridesFile
+	if err := decoder.Decode(&file); err != nil && err != io.EOF {
+		return result, fmt.Errorf("failed to parse overrides file: %w", err)
+	}
+
+	overrides := make([]database.ChangeOverride, 0, len(file.Overrides))
+	for i, e := range file.Overrides {
+		o, err := e.toOverride()
+		if err == nil {
+			err = database.ValidateOverride(o)
+		}
+		if err != nil {
+			return result, fmt.Errorf("override #%d: %w", i+1, err)
+		}
+		overrides = append(overrides, o)
+	}
+
+	saved, err := db.SaveOverrides(overrides, actor, path)
+	if err != nil {
+		return result, err
+	}
+	for _, s := range saved {
+		switch s.Action {
+		case database.OverrideCreated:
+			result.Created++
+		case database.OverrideUpdated:
+			result.Updated++
+		default:
+			result.Unchanged++
+			continue
+		}
+		log.Printf("上書き設定 #%d を保存しました (%s: Go %s %s)", s.Override.ID, s.Action, s.Override.Key.Version, s.Override.Key.Package)
+	}
+	return result, nil
+}
+
+func (e OverrideEntry) toOverride() (database.ChangeOverride, error) {
+	hash := e.DescriptionHash
+	if e.Description != "" {
+		if hash != "" && hash != database.DescriptionHash(e.Description) {
+			return database.ChangeOverride{}, fmt.Errorf("description and description_hash do not match")
+		}
+		hash = database.DescriptionHash(e.Description)
+	}
+	if hash == "" {
+		return database.ChangeOverride{}, fmt.Errorf("either description or description_hash is required")
+	}
+
+	return database.ChangeOverride{
+		Key: database.ChangeKey{
+			Version:         strings.TrimPrefix(e.Version, "go"),
+			Area:            e.Area,
+			Package:         e.Package,
+			DescriptionHash: hash,
+		},
+		ChangeType: e.ChangeType,
+		Package:    e.SetPackage,
+		SummaryJa:  e.SummaryJa,
+		Hidden:     e.Hidden,
+		Note:       e.Note,
+	}, nil
+}
@@ -0,0 +1,244 @@
+package importer
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"go-ver-trace/internal/database"
+	"go-ver-trace/internal/langtag"
+)
+
+// GlossaryColumns is the header of the glossary CSV file
+//
+//	term,lang,translation,note
+//	goroutine,ja,goroutine,英語のまま
+//	context cancellation,ja,コンテキストのキャンセル,
+var GlossaryColumns = []string{"term", "lang", "translation", "note"}
+
+// TranslationColumns is the header of the translations CSV file
+// Changes are identified by their natural key because change IDs are reassigned on every refresh.
+// current_summary and current_status are for reference only; rows with an empty summary are not imported.
+var TranslationColumns = []string{"version", "area", "package", "description_hash", "lang", "description", "current_summary", "current_status", "summary"}
+
+// TranslationsImportResult counts the translations saved, left unchanged and not matching any change
+type TranslationsImportResult struct {
+	Saved     int
+	Unchanged int
+	Unmatched int
+}
+
+// ExportGlossary writes the glossary of lang (every language if empty) to a CSV file
+func ExportGlossary(db *database.Database, path, lang string) (int, error) {
+	terms, err := db.GetGlossary(lang)
+	if err != nil {
+		return 0, err
+	}
+
+	rows := make([][]string, 0, len(terms))
+	for _, t := range terms {
+		rows = append(rows, []string{t.Term, t.Lang, t.Translation, t.Note})
+	}
+	return len(rows), writeCSV(path, GlossaryColumns, rows)
+}
+
+// ImportGlossary reads a glossary CSV file and saves every term as actor
+// All rows are validated before anything is saved
+func ImportGlossary(db *database.Database, path, actor string) (database.GlossaryResult, error) {
+	records, err := readCSV(path, []string{"term", "lang", "translation"})
+	if err != nil {
+		return database.GlossaryResult{}, err
+	}
+
+	terms := make([]database.GlossaryTerm, 0, len(records))
+	for i, r := range records {
+		t, err := database.ValidateGlossaryTerm(database.GlossaryTerm{
+			Term:        r["term"],
+			Lang:        r["lang"],
+			Translation: r["translation"],
+			Note:        r["note"],
+		})
+		if err != nil {
+			return database.GlossaryResult{}, fmt.Errorf("line %d: %w", i+2, err)
+		}
+		terms = append(terms, t)
+	}
+	return db.SaveGlossaryTerms(terms, actor)
+}
+
+// ExportTranslations writes every change with its current summary in lang to a CSV file for offline translation
+// Reviewed summaries are exported in the summary column, machine and rejected ones only in current_summary
+func ExportTranslations(db *database.Database, path, lang string) (int, error) {
+	lang, err := langtag.Normalize(lang)
+	if err != nil {
+		return 0, err
+	}
+	changes, err := db.GetAllPackageChanges()
+	if err != nil {
+		return 0, err
+	}
+	keys, err := db.GetChangeKeys()
+	if err != nil {
+		return 0, err
+	}
+	translations, err := db.GetTranslationsIn(lang)
+	if err != nil {
+		return 0, err
+	}
+
+	var rows [][]string
+	seen := make(map[database.ChangeKey]bool)
+	for _, c := range changes {
+		key := keys[c.ID]
+		if c.Description == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		t := translations[c.ID]
+		summary := ""
+		if t.Status == database.TranslationReviewed {
+			summary = t.Summary
+		}
+		rows = append(rows, []string{key.Version, key.Area, key.Package, key.DescriptionHash, lang, c.Description, t.Summary, t.Status, summary})
+	}
+	return len(rows), writeCSV(path, TranslationColumns, rows)
+}
+
+// ImportTranslations reads a translations CSV file and saves every non-empty summary as reviewed by actor
+// All rows are validated before anything is saved; rows whose change no longer exists are counted as unmatched
+func ImportTranslations(db *database.Database, path, actor string) (TranslationsImportResult, error) {
+	var result TranslationsImportResult
+
+	records, err := readCSV(path, []string{"version", "package", "lang", "summary"})
+	if err != nil {
+		return result, err
+	}
+	keys, err := db.GetChangeKeys()
+	if err != nil {
+		return result, err
+	}
+	changeIDs := make(map[database.ChangeKey][]int)
+	for id, key := range keys {
+		changeIDs[key] = append(changeIDs[key], id)
+	}
+
+	var translations []database.ChangeTranslation
+	for i, r := range records {
+		line := i + 2
+		summary := strings.TrimSpace(r["summary"])
+		if summary == "" {
+			continue
+		}
+		lang, err := langtag.Normalize(r["lang"])
+		if err != nil {
+			return result, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		hash := r["description_hash"]
+		if r["description"] != "" {
+			if hash != "" && hash != database.DescriptionHash(r["description"]) {
+				return result, fmt.Errorf("line %d: description and description_hash do not match", line)
+			}
+			hash = database.DescriptionHash(r["description"])
+		}
+		if r["version"] == "" || r["package"] == "" || hash == "" {
+			return result, fmt.Errorf("line %d: version, package and description or description_hash are required", line)
+		}
+		area := r["area"]
+		if area == "" {
+			area = database.DefaultArea
+		}
+
+		key := database.ChangeKey{Version: strings.TrimPrefix(r["version"], "go"), Area: area, Package: r["package"], DescriptionHash: hash}
+		ids := changeIDs[key]
+		if len(ids) == 0 {
+			log.Printf("一致する変更がありません (%d 行目: Go %s %s)", line, key.Version, key.Package)
+			result.Unmatched++
+			continue
+		}
+		sort.Ints(ids)
+		for _, id := range ids {
+			translations = append(translations, database.ChangeTranslation{ChangeID: id, Lang: lang, Summary: summary})
+		}
+	}
+
+	saved, err := db.SaveTranslations(translations, actor)
+	if err != nil {
+		return TranslationsImportResult{}, err
+	}
+	result.Saved = saved
+	result.Unchanged = len(translations) - saved
+	return result, nil
+}
+
+// readCSV reads a CSV file with a header row and returns each row keyed by column name
+func readCSV(path string, required []string) ([]map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%s is empty", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(strings.TrimPrefix(header[i], "\ufeff"))
+	}
+	for _, column := range required {
+		found := false
+		for _, h := range header {
+			found = found || h == column
+		}
+		if !found {
+			return nil, fmt.Errorf("%s: missing column %q", path, column)
+		}
+	}
+
+	var records []map[string]string
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		record := make(map[string]string, len(header))
+		for i, h := range header {
+			if i < len(row) {
+				record[h] = strings.TrimSpace(row[i])
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// writeCSV writes a CSV file with a header row
+func writeCSV(path string, header []string, rows [][]string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+
+	w := csv.NewWriter(f)
+	w.Write(header)
+	w.WriteAll(rows)
+	if err := w.Error(); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return f.Close()
+}
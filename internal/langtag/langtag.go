@@ -0,0 +1,132 @@
+// Package langtag は要約の言語を表す BCP-47 言語タグ（"ja", "ko", "zh-Hans", "pt-BR"）の正規化と選択を行う
+package langtag
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Source は変更の説明文（リリースノートの原文）の言語
+const Source = "en"
+
+// Normalize は言語タグを検証し、大文字・小文字を BCP-47 の慣例に揃える（"ZH-hant-tw" → "zh-Hant-TW"）
+// 区切りの "_" は "-" として扱う
+func Normalize(tag string) (string, error) {
+	raw := tag
+	tag = strings.ReplaceAll(strings.TrimSpace(tag), "_", "-")
+	if tag == "" {
+		return "", fmt.Errorf("empty language tag")
+	}
+
+	subtags := strings.Split(tag, "-")
+	for i, s := range subtags {
+		if len(s) == 0 || len(s) > 8 || !isAlphanumeric(s) {
+			return "", fmt.Errorf("invalid language tag: %q", raw)
+		}
+		switch {
+		case i == 0:
+			if len(s) < 2 || !isAlpha(s) {
+				return "", fmt.Errorf("invalid language tag: %q", raw)
+			}
+			subtags[i] = strings.ToLower(s)
+		case len(s) == 4 && isAlpha(s):
+			// 用字（Hant, Latn など）
+			subtags[i] = strings.ToUpper(s[:1]) + strings.ToLower(s[1:])
+		case len(s) == 2 && isAlpha(s):
+			// 地域（TW, BR など）
+			subtags[i] = strings.ToUpper(s)
+		default:
+			subtags[i] = strings.ToLower(s)
+		}
+	}
+	return strings.Join(subtags, "-"), nil
+}
+
+// Primary は言語タグの主言語（"zh-Hant-TW" → "zh"）
+func Primary(tag string) string {
+	primary, _, _ := strings.Cut(tag, "-")
+	return strings.ToLower(primary)
+}
+
+// ParseAcceptLanguage は Accept-Language ヘッダーの言語を q 値の高い順に返す
+// 解釈できない言語・"*"・q=0 の言語は無視する
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+
+	var langs []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			name, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if !found || strings.TrimSpace(name) != "q" {
+				continue
+			}
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				q = 0
+			} else {
+				q = parsed
+			}
+		}
+		if q <= 0 || strings.TrimSpace(tag) == "*" {
+			continue
+		}
+		normalized, err := Normalize(tag)
+		if err != nil {
+			continue
+		}
+		langs = append(langs, weighted{tag: normalized, q: q})
+	}
+
+	sort.SliceStable(langs, func(i, j int) bool { return langs[i].q > langs[j].q })
+	tags := make([]string, 0, len(langs))
+	for _, l := range langs {
+		tags = append(tags, l.tag)
+	}
+	return tags
+}
+
+// Match は希望順の言語 prefs から available の中で最初に一致する言語を返す
+// 完全に一致する言語がなければ主言語が同じ言語を使う（"zh-TW" を希望した場合の "zh-Hant" など）
+func Match(prefs, available []string) (string, bool) {
+	sorted := append([]string(nil), available...)
+	sort.Strings(sorted)
+
+	for _, pref := range prefs {
+		for _, tag := range sorted {
+			if strings.EqualFold(pref, tag) {
+				return tag, true
+			}
+		}
+		for _, tag := range sorted {
+			if Primary(pref) == Primary(tag) {
+				return tag, true
+			}
+		}
+	}
+	return "", false
+}
+
+func isAlpha(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlphanumeric(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
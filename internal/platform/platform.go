@@ -0,0 +1,81 @@
+// Package platform は変更が対象とする GOOS/GOARCH の組の表現と解析を行う
+package platform
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Platform は変更が対象とする GOOS/GOARCH（空の場合はその軸を限定しない）
+type Platform struct {
+	GOOS   string `json:"goos,omitempty"`
+	GOARCH string `json:"goarch,omitempty"`
+}
+
+// String は "linux/amd64"、"windows"（GOOS のみ）、"*/arm64"（GOARCH のみ）の形式で返す
+func (p Platform) String() string {
+	switch {
+	case p.GOARCH == "":
+		return p.GOOS
+	case p.GOOS == "":
+		return "*/" + p.GOARCH
+	default:
+		return p.GOOS + "/" + p.GOARCH
+	}
+}
+
+// Matches は変更の対象プラットフォーム p が target（linux/amd64 など）に該当するか判定する
+func (p Platform) Matches(target Platform) bool {
+	return (p.GOOS == "" || target.GOOS == "" || p.GOOS == target.GOOS) &&
+		(p.GOARCH == "" || target.GOARCH == "" || p.GOARCH == target.GOARCH)
+}
+
+// KnownGOOS は扱う GOOS の一覧
+var KnownGOOS = []string{
+	"aix", "android", "darwin", "dragonfly", "freebsd", "illumos", "ios", "js",
+	"linux", "netbsd", "openbsd", "plan9", "solaris", "wasip1", "windows",
+}
+
+// KnownGOARCH は扱う GOARCH の一覧
+var KnownGOARCH = []string{
+	"386", "amd64", "arm", "arm64", "loong64", "mips", "mips64", "mips64le", "mipsle",
+	"ppc64", "ppc64le", "riscv64", "s390x", "wasm",
+}
+
+// Parse は "linux/amd64"、"windows"、"*/arm64" 形式の指定を解析する
+func Parse(s string) (Platform, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	goos, goarch, hasArch := strings.Cut(s, "/")
+	if goos == "*" {
+		goos = ""
+	}
+
+	p := Platform{GOOS: goos, GOARCH: goarch}
+	if p.GOOS != "" && !slices.Contains(KnownGOOS, p.GOOS) {
+		return Platform{}, fmt.Errorf("unknown GOOS %q in platform %q", p.GOOS, s)
+	}
+	if hasArch && !slices.Contains(KnownGOARCH, p.GOARCH) {
+		return Platform{}, fmt.Errorf("unknown GOARCH %q in platform %q", p.GOARCH, s)
+	}
+	if p.GOOS == "" && p.GOARCH == "" {
+		return Platform{}, fmt.Errorf("invalid platform %q", s)
+	}
+	return p, nil
+}
+
+// ParseList はカンマ区切りのプラットフォーム指定（"linux/amd64,linux/arm64"）を解析する
+func ParseList(param string) ([]Platform, error) {
+	var platforms []Platform
+	for _, s := range strings.Split(param, ",") {
+		if strings.TrimSpace(s) == "" {
+			continue
+		}
+		p, err := Parse(s)
+		if err != nil {
+			return nil, err
+		}
+		platforms = append(platforms, p)
+	}
+	return platforms, nil
+}
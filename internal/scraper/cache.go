@@ -0,0 +1,92 @@
+package scraper
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultHTTPCacheDir は HTTP キャッシュの既定の保存先
+const DefaultHTTPCacheDir = "http-cache"
+
+// HTTPCache は取得したページの本文と検証用ヘッダーをディレクトリに保存する
+// 次回の取得時に If-None-Match / If-Modified-Since を送り、304 の場合は保存済みの本文を使う
+type HTTPCache struct {
+	dir string
+}
+
+// cacheEntry は URL ごとの保存内容（本文は別ファイル）
+type cacheEntry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	FetchedAt    time.Time `json:"fetched_at"`
+}
+
+// NewHTTPCache は dir に保存する HTTP キャッシュを作成する
+func NewHTTPCache(dir string) (*HTTPCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create HTTP cache directory: %w", err)
+	}
+	return &HTTPCache{dir: dir}, nil
+}
+
+func (c *HTTPCache) path(url, ext string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+ext)
+}
+
+// get は保存済みのエントリと本文を返す（どちらかが読めない場合は ok = false）
+func (c *HTTPCache) get(url string) (cacheEntry, []byte, bool) {
+	data, err := os.ReadFile(c.path(url, ".json"))
+	if err != nil {
+		return cacheEntry{}, nil, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.URL != url {
+		return cacheEntry{}, nil, false
+	}
+	body, err := os.ReadFile(c.path(url, ".body"))
+	if err != nil {
+		return cacheEntry{}, nil, false
+	}
+	return entry, body, true
+}
+
+// put は本文と検証用ヘッダーを保存する（ETag も Last-Modified もない応答は保存しない）
+func (c *HTTPCache) put(entry cacheEntry, body []byte) error {
+	if entry.ETag == "" && entry.LastModified == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return err
+	}
+	// 本文を先に書き、エントリが本文より新しくならないようにする
+	if err := writeFileAtomic(c.path(entry.URL, ".body"), body); err != nil {
+		return err
+	}
+	return writeFileAtomic(c.path(entry.URL, ".json"), data)
+}
+
+// writeFileAtomic は一時ファイルに書いてから置き換える（並行して取得するワーカーが途中の内容を読まないように）
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
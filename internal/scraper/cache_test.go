@@ -0,0 +1,113 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+// TestFetchRevalidatesCache は保存済みの ETag・Last-Modified で条件付きリクエストを送り、
+// 304 の場合は保存済みの本文を使うことを確認する
+func TestFetchRevalidatesCache(t *testing.T) {
+	const url = "https://go.dev/doc/go1.22"
+
+	tests := []struct {
+		name       string
+		header     string // 1 回目の応答に付ける検証用ヘッダー
+		value      string
+		condition  string // 2 回目のリクエストで期待する条件付きヘッダー
+		wantCached bool
+	}{
+		{name: "etag", header: "ETag", value: `"v1"`, condition: "If-None-Match", wantCached: true},
+		{name: "last-modified", header: "Last-Modified", value: "Tue, 06 Feb 2024 18:00:00 GMT", condition: "If-Modified-Since", wantCached: true},
+		// 検証用ヘッダーのない応答は保存しない
+		{name: "no validator", wantCached: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache, err := NewHTTPCache(t.TempDir())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			calls := 0
+			rs := newStubScraper(func(req *http.Request) (*http.Response, error) {
+				calls++
+				if calls == 1 {
+					resp := stubResponse(http.StatusOK, "release notes")
+					if tt.header != "" {
+						resp.Header.Set(tt.header, tt.value)
+					}
+					return resp, nil
+				}
+				if tt.condition != "" && req.Header.Get(tt.condition) == tt.value {
+					return stubResponse(http.StatusNotModified, ""), nil
+				}
+				return stubResponse(http.StatusOK, "updated release notes"), nil
+			})
+			rs.SetCache(cache)
+
+			if _, notModified, err := rs.fetch(context.Background(), url); err != nil || notModified {
+				t.Fatalf("first fetch: notModified = %v, err = %v", notModified, err)
+			}
+
+			body, notModified, err := rs.fetch(context.Background(), url)
+			if err != nil {
+				t.Fatalf("second fetch: %v", err)
+			}
+			if notModified != tt.wantCached {
+				t.Errorf("notModified = %v, want %v", notModified, tt.wantCached)
+			}
+			wantBody, wantHits := "updated release notes", 0
+			if tt.wantCached {
+				wantBody, wantHits = "release notes", 1
+			}
+			if string(body) != wantBody {
+				t.Errorf("body = %q, want %q", body, wantBody)
+			}
+			if rs.CacheHits() != wantHits {
+				t.Errorf("CacheHits = %d, want %d", rs.CacheHits(), wantHits)
+			}
+		})
+	}
+}
+
+// TestGetReleaseInfoSkipsUnchanged は更新されていない保存済みのリリースの解析を省略することを確認する
+func TestGetReleaseInfoSkipsUnchanged(t *testing.T) {
+	cache, err := NewHTTPCache(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rs := newStubScraper(func(req *http.Request) (*http.Response, error) {
+		if req.Header.Get("If-None-Match") == `"v1"` {
+			return stubResponse(http.StatusNotModified, ""), nil
+		}
+		resp := stubResponse(http.StatusOK, `<h2 id="library">Standard library</h2>`)
+		resp.Header.Set("ETag", `"v1"`)
+		return resp, nil
+	})
+	rs.SetCache(cache)
+	rs.SkipUnchanged = func(version string) bool { return version == "1.22" }
+
+	// 1 回目で本文をキャッシュに保存する
+	if _, err := rs.GetReleaseInfo(context.Background(), []string{"1.22", "1.23"}); err != nil {
+		t.Fatal(err)
+	}
+
+	releases, err := rs.GetReleaseInfo(context.Background(), []string{"1.22", "1.23"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(releases) != 2 {
+		t.Fatalf("got %d releases, want 2", len(releases))
+	}
+	if !releases[0].NotModified {
+		t.Error("Go 1.22: NotModified = false, want true for a saved release")
+	}
+	// 保存済みでないリリースは更新されていなくても解析する
+	if releases[1].NotModified {
+		t.Error("Go 1.23: NotModified = true, want false for an unsaved release")
+	}
+}
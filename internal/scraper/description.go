@@ -0,0 +1,198 @@
+package scraper
+
+import (
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// 要約表示用の抜粋の最大文字数（rune 単位）
+const excerptMaxRunes = 200
+
+// 相対リンクを解決する基準 URL
+var releaseNotesBaseURL, _ = url.Parse("https://go.dev/doc/")
+
+// 説明文として保持を許可するタグ
+var allowedDescriptionTags = map[string]bool{
+	"p": true, "a": true, "code": true, "pre": true, "em": true, "strong": true,
+	"i": true, "b": true, "ul": true, "ol": true, "li": true, "dl": true,
+	"dt": true, "dd": true, "blockquote": true, "br": true, "kbd": true,
+	"var": true, "sub": true, "sup": true,
+}
+
+// 中身ごと破棄するタグ
+var droppedDescriptionTags = map[string]bool{
+	"script": true, "style": true, "iframe": true, "object": true, "embed": true,
+	"form": true, "input": true, "button": true, "textarea": true, "select": true,
+}
+
+// description は抽出した説明文のプレーンテキストとサニタイズ済み HTML の組
+type description struct {
+	texts []string
+	htmls []string
+	links []ChangeLink
+}
+
+// add は要素のテキストと HTML を説明文に追加する（空の要素は無視）
+func (d *description) add(sel *goquery.Selection) {
+	text := strings.TrimSpace(plainText(sel))
+	if text == "" {
+		return
+	}
+	d.texts = append(d.texts, text)
+	d.htmls = append(d.htmls, sanitizeHTML(sel))
+
+	// 要素内のリンクを参照として保持（同一 URL は 1 件にまとめる）
+	sel.Find("a[href]").AddSelection(sel.Filter("a[href]")).Each(func(i int, a *goquery.Selection) {
+		href, _ := a.Attr("href")
+		link, ok := NewChangeLink(href, a.Text())
+		if !ok {
+			return
+		}
+		for _, existing := range d.links {
+			if existing.URL == link.URL {
+				return
+			}
+		}
+		d.links = append(d.links, link)
+	})
+}
+
+func (d description) Text() string {
+	return strings.Join(d.texts, " ")
+}
+
+func (d description) HTML() string {
+	return strings.Join(d.htmls, "\n")
+}
+
+func (d description) Links() []ChangeLink {
+	return d.links
+}
+
+func (d description) empty() bool {
+	return len(d.texts) == 0
+}
+
+// length は収集済みテキストの長さ（探索の打ち切り判定用）
+func (d description) length() int {
+	return len(d.Text())
+}
+
+// MakeExcerpt は説明文を rune 境界で切り詰めた抜粋を返す
+func MakeExcerpt(text string) string {
+	text = strings.TrimSpace(text)
+	if utf8.RuneCountInString(text) <= excerptMaxRunes {
+		return text
+	}
+
+	runes := []rune(text)
+	return strings.TrimSpace(string(runes[:excerptMaxRunes])) + "..."
+}
+
+// plainText は script などの破棄対象タグを除いたテキストを返す
+func plainText(sel *goquery.Selection) string {
+	var b strings.Builder
+	var walk func(node *html.Node)
+	walk = func(node *html.Node) {
+		if node.Type == html.TextNode {
+			b.WriteString(node.Data)
+			return
+		}
+		if node.Type == html.ElementNode && droppedDescriptionTags[strings.ToLower(node.Data)] {
+			return
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	for _, node := range sel.Nodes {
+		walk(node)
+	}
+	return b.String()
+}
+
+// sanitizeHTML は選択要素を許可タグのみの HTML として書き出す
+// 要素自体が許可タグであればそのタグごと出力する
+func sanitizeHTML(sel *goquery.Selection) string {
+	var b strings.Builder
+	for _, node := range sel.Nodes {
+		writeSanitizedNode(&b, node)
+	}
+	return b.String()
+}
+
+func writeSanitizedNode(b *strings.Builder, node *html.Node) {
+	switch node.Type {
+	case html.TextNode:
+		b.WriteString(html.EscapeString(node.Data))
+		return
+	case html.ElementNode:
+		// 以下で処理
+	default:
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			writeSanitizedNode(b, child)
+		}
+		return
+	}
+
+	tag := strings.ToLower(node.Data)
+	if droppedDescriptionTags[tag] {
+		return
+	}
+
+	// 許可されていないタグは中身のみ残す
+	if !allowedDescriptionTags[tag] {
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			writeSanitizedNode(b, child)
+		}
+		return
+	}
+
+	b.WriteString("<" + tag)
+	if tag == "a" {
+		if href := sanitizeHref(attrValue(node, "href")); href != "" {
+			b.WriteString(` href="` + html.EscapeString(href) + `"`)
+		}
+	}
+	if tag == "br" {
+		b.WriteString(">")
+		return
+	}
+	b.WriteString(">")
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		writeSanitizedNode(b, child)
+	}
+	b.WriteString("</" + tag + ">")
+}
+
+func attrValue(node *html.Node, name string) string {
+	for _, attr := range node.Attr {
+		if strings.EqualFold(attr.Key, name) {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+// sanitizeHref は http(s) のリンクのみを許可し、相対リンクは go.dev 基準で絶対 URL にする
+func sanitizeHref(href string) string {
+	href = strings.TrimSpace(href)
+	if href == "" {
+		return ""
+	}
+
+	u, err := url.Parse(href)
+	if err != nil {
+		return ""
+	}
+	resolved := releaseNotesBaseURL.ResolveReference(u)
+	if resolved.Scheme != "http" && resolved.Scheme != "https" {
+		return ""
+	}
+	return resolved.String()
+}
@@ -0,0 +1,57 @@
+package scraper
+
+import (
+	"fmt"
+	"log"
+	"sort"
+)
+
+// 解析時の診断の種別
+const (
+	DiagnosticFetchFailed      = "fetch_failed"      // リリースノートを取得・解析できなかった（過去リリース以外はダミーデータを使用した）
+	DiagnosticUnparsedSection  = "unparsed_section"  // 節のレイアウトを判定できなかった
+	DiagnosticUnparsedHeading  = "unparsed_heading"  // 見出し・項目からパッケージ名を抽出できなかった
+	DiagnosticEmptyDescription = "empty_description" // パッケージ名は抽出できたが説明文が空だった
+	DiagnosticUnknownPackage   = "unknown_package"   // isKnownStandardPackage で除外したパッケージ名
+	DiagnosticDuplicatePackage = "duplicate_package" // 同じリリースの標準ライブラリで同じパッケージが複数回抽出された
+)
+
+// Diagnostic はリリースノートの解析中に見つかった問題
+type Diagnostic struct {
+	Version string
+	Kind    string
+	Subject string // 対象（見出し・パッケージ名など）
+	Detail  string
+}
+
+// report は診断を記録し、ログにも出力する
+func (ctx *parseContext) report(kind, subject, detail string) {
+	ctx.diagnostics = append(ctx.diagnostics, Diagnostic{
+		Version: ctx.version,
+		Kind:    kind,
+		Subject: subject,
+		Detail:  detail,
+	})
+	log.Printf("Go %s: [%s] %s (%s)", ctx.version, kind, subject, detail)
+}
+
+// reportDuplicates は標準ライブラリの変更で複数回抽出されたパッケージを記録する
+func (ctx *parseContext) reportDuplicates(changes []StandardLibraryChange) {
+	subheadings := make(map[string][]string)
+	for _, change := range changes {
+		subheadings[change.Package] = append(subheadings[change.Package], change.Subheading)
+	}
+
+	var packages []string
+	for pkg, headings := range subheadings {
+		if len(headings) > 1 {
+			packages = append(packages, pkg)
+		}
+	}
+	sort.Strings(packages)
+
+	for _, pkg := range packages {
+		ctx.report(DiagnosticDuplicatePackage, pkg,
+			fmt.Sprintf("%d 回抽出 (見出し: %q)", len(subheadings[pkg]), subheadings[pkg]))
+	}
+}
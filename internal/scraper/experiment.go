@@ -0,0 +1,64 @@
+package scraper
+
+import (
+	"regexp"
+	"strings"
+)
+
+// 実験的機能（GOEXPERIMENT・実験的パッケージ）の状態
+const (
+	ExperimentStatusExperimental = "experimental" // GOEXPERIMENT の指定が必要
+	ExperimentStatusDefaultOn    = "default_on"   // デフォルトで有効
+	ExperimentStatusRemoved      = "removed"      // GOEXPERIMENT が削除された（無効化できない、または機能ごと削除）
+)
+
+var goexperimentRegex = regexp.MustCompile(`GOEXPERIMENT=([a-z][a-z0-9]*)`)
+
+// GOEXPERIMENT 名が本文に出てこない実験的パッケージと実験名の対応
+var experimentalPackages = map[string]string{
+	"encoding/json/v2":       "jsonv2",
+	"encoding/json/jsontext": "jsonv2",
+	"testing/synctest":       "synctest",
+	"arena":                  "arenas",
+	"simd":                   "simd",
+}
+
+var (
+	experimentDefaultOnRegex = regexp.MustCompile(`(?i)(enabled by default|is now (?:the )?default|now on by default|no longer requires? (?:the )?GOEXPERIMENT|no longer need(?:s)? (?:to set )?GOEXPERIMENT)`)
+	experimentRemovedRegex   = regexp.MustCompile(`(?i)(can no longer be disabled|GOEXPERIMENT[^.]*\b(?:removed|no longer (?:supported|available))|experiment (?:has been|was) removed)`)
+)
+
+// detectExperiment は見出しと説明文から実験名と状態を判定する（該当しない場合は空文字列）
+func detectExperiment(packageName, heading, text string) (string, string) {
+	name := ""
+	if m := goexperimentRegex.FindStringSubmatch(text); m != nil {
+		name = m[1]
+	} else if m := goexperimentRegex.FindStringSubmatch(heading); m != nil {
+		name = m[1]
+	} else if exp, ok := experimentalPackages[packageName]; ok &&
+		(strings.Contains(strings.ToLower(heading), "experimental") || strings.Contains(strings.ToLower(text), "experimental")) {
+		name = exp
+	}
+
+	if name == "" {
+		return "", ""
+	}
+
+	switch {
+	case experimentRemovedRegex.MatchString(text):
+		return name, ExperimentStatusRemoved
+	case experimentDefaultOnRegex.MatchString(text):
+		return name, ExperimentStatusDefaultOn
+	default:
+		return name, ExperimentStatusExperimental
+	}
+}
+
+// tagExperiments は変更に実験名と状態を設定する
+func tagExperiments(changes []StandardLibraryChange) {
+	for i := range changes {
+		name, status := detectExperiment(changes[i].Package, changes[i].Subheading, changes[i].Description)
+		changes[i].Experiment = name
+		changes[i].ExperimentStatus = status
+	}
+}
@@ -0,0 +1,238 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// スクレイピングの既定値
+const (
+	DefaultWorkers        = 4               // 同時に取得するリリースノートの数
+	DefaultRequestsPerSec = 2.0             // go.dev へのリクエストの上限（1 秒あたり）
+	defaultMaxRetries     = 4               // 429・5xx・通信エラー時の再試行回数
+	defaultRetryBaseDelay = 1 * time.Second // 再試行の初回待機時間（以降は 2 倍ずつ）
+	maxRetryDelay         = 30 * time.Second
+)
+
+// rateLimiter はトークンバケット方式でリクエストの間隔を制限する
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration // トークン 1 つが補充されるまでの時間
+	burst    float64
+	tokens   float64
+	last     time.Time
+}
+
+func newRateLimiter(perSecond float64, burst int) *rateLimiter {
+	if perSecond <= 0 {
+		perSecond = DefaultRequestsPerSec
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		interval: time.Duration(float64(time.Second) / perSecond),
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}
+}
+
+// Wait はトークンを 1 つ取得できるまで待つ（ctx がキャンセルされた場合はエラーを返す）
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		l.tokens = math.Min(l.burst, l.tokens+float64(now.Sub(l.last))/float64(l.interval))
+		l.last = now
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - l.tokens) * float64(l.interval))
+		l.mu.Unlock()
+
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// SetConcurrency は同時に取得するリリースノートの数と 1 秒あたりのリクエスト数の上限を設定する
+func (rs *ReleaseScraper) SetConcurrency(workers int, requestsPerSec float64) {
+	if workers < 1 {
+		workers = 1
+	}
+	rs.workers = workers
+	rs.limiter = newRateLimiter(requestsPerSec, workers)
+}
+
+// httpStatusError は 200 以外の HTTP ステータスの応答
+type httpStatusError struct {
+	url    string
+	status int
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("GET %s: %d %s", e.url, e.status, http.StatusText(e.status))
+}
+
+// SetCache は HTTP キャッシュを設定する（nil の場合は毎回取得する）
+func (rs *ReleaseScraper) SetCache(cache *HTTPCache) {
+	rs.cache = cache
+}
+
+// CacheHits は 304 Not Modified で保存済みの本文を使った回数を返す
+func (rs *ReleaseScraper) CacheHits() int {
+	return int(rs.cacheHits.Load())
+}
+
+// fetch は url の本文を取得する
+// レート制限に従い、429・5xx・通信エラーの場合は Retry-After または指数バックオフで待って再試行する
+// HTTP キャッシュがある場合は条件付きリクエストを送り、更新されていなければ保存済みの本文と notModified = true を返す
+func (rs *ReleaseScraper) fetch(ctx context.Context, url string) (body []byte, notModified bool, err error) {
+	var lastErr error
+	for attempt := 0; attempt <= rs.maxRetries; attempt++ {
+		if attempt > 0 {
+			delay := retryDelay(attempt, lastErr)
+			log.Printf("%s の取得を %s 後に再試行します (%d/%d): %v", url, delay, attempt, rs.maxRetries, lastErr)
+			if err := sleepContext(ctx, delay); err != nil {
+				return nil, false, err
+			}
+		}
+
+		if err := rs.limiter.Wait(ctx); err != nil {
+			return nil, false, err
+		}
+
+		body, notModified, retryable, err := rs.fetchOnce(ctx, url)
+		if err == nil {
+			return body, notModified, nil
+		}
+		if ctx.Err() != nil {
+			return nil, false, ctx.Err()
+		}
+		if !retryable {
+			return nil, false, err
+		}
+		lastErr = err
+	}
+	return nil, false, lastErr
+}
+
+// retryAfterError は Retry-After ヘッダーで待機時間を指定された応答
+type retryAfterError struct {
+	httpStatusError
+	after time.Duration
+}
+
+// fetchOnce は 1 回だけ取得し、失敗した場合は再試行できるかどうかを返す
+func (rs *ReleaseScraper) fetchOnce(ctx context.Context, url string) (body []byte, notModified, retryable bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, false, false, err
+	}
+
+	var cached cacheEntry
+	var cachedBody []byte
+	hasCache := false
+	if rs.cache != nil {
+		if cached, cachedBody, hasCache = rs.cache.get(url); hasCache {
+			if cached.ETag != "" {
+				req.Header.Set("If-None-Match", cached.ETag)
+			}
+			if cached.LastModified != "" {
+				req.Header.Set("If-Modified-Since", cached.LastModified)
+			}
+		}
+	}
+
+	resp, err := rs.client.Do(req)
+	if err != nil {
+		// ホスト名が存在しない場合を除き、通信エラーは再試行する
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return nil, false, false, err
+		}
+		return nil, false, true, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && hasCache {
+		rs.cacheHits.Add(1)
+		log.Printf("%s は更新されていません（キャッシュを使用）", url)
+		return cachedBody, true, false, nil
+	}
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+		statusErr := httpStatusError{url: url, status: resp.StatusCode}
+		if after, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return nil, false, true, &retryAfterError{httpStatusError: statusErr, after: after}
+		}
+		return nil, false, true, &statusErr
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, false, &httpStatusError{url: url, status: resp.StatusCode}
+	}
+
+	body, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, true, fmt.Errorf("failed to read %s: %w", url, err)
+	}
+
+	if rs.cache != nil {
+		entry := cacheEntry{
+			URL:          url,
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			FetchedAt:    time.Now(),
+		}
+		if err := rs.cache.put(entry, body); err != nil {
+			log.Printf("%s をキャッシュに保存できませんでした: %v", url, err)
+		}
+	}
+	return body, false, false, nil
+}
+
+// retryDelay は再試行までの待機時間を返す（Retry-After の指定があればそれを優先する）
+func retryDelay(attempt int, lastErr error) time.Duration {
+	if ra, ok := lastErr.(*retryAfterError); ok {
+		return min(ra.after, maxRetryDelay)
+	}
+	return min(defaultRetryBaseDelay<<(attempt-1), maxRetryDelay)
+}
+
+// parseRetryAfter は Retry-After ヘッダー（秒数または HTTP 日付）を解析する
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
+
+// sleepContext は d だけ待つ（ctx がキャンセルされた場合はすぐにエラーを返す）
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
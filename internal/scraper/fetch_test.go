@@ -0,0 +1,122 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestFetchRetry は 429・5xx を再試行し、それ以外のエラーは再試行しないことを確認する
+func TestFetchRetry(t *testing.T) {
+	tests := []struct {
+		name      string
+		statuses  []int // 応答するステータス（最後のステータスを以降も返す）
+		wantCalls int
+		wantErr   bool
+	}{
+		{name: "succeeds after 503", statuses: []int{503, 503, 200}, wantCalls: 3},
+		{name: "succeeds after 429", statuses: []int{429, 200}, wantCalls: 2},
+		{name: "gives up after max retries", statuses: []int{502}, wantCalls: 1 + 2, wantErr: true},
+		{name: "does not retry 404", statuses: []int{404}, wantCalls: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			rs := newStubScraper(func(req *http.Request) (*http.Response, error) {
+				status := tt.statuses[min(calls, len(tt.statuses)-1)]
+				calls++
+				resp := stubResponse(status, "release notes")
+				// 再試行までの待機時間を 0 にする
+				resp.Header.Set("Retry-After", "0")
+				return resp, nil
+			})
+			rs.maxRetries = 2
+
+			body, _, err := rs.fetch(context.Background(), "https://go.dev/doc/go1.22")
+			if calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls, tt.wantCalls)
+			}
+			if tt.wantErr {
+				if err == nil {
+					t.Error("fetch succeeded, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("fetch: %v", err)
+			}
+			if string(body) != "release notes" {
+				t.Errorf("body = %q", body)
+			}
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	retryAfter := func(d time.Duration) error {
+		return &retryAfterError{httpStatusError: httpStatusError{status: 429}, after: d}
+	}
+	tests := []struct {
+		attempt int
+		lastErr error
+		want    time.Duration
+	}{
+		{attempt: 1, lastErr: errors.New("connection reset"), want: 1 * time.Second},
+		{attempt: 3, lastErr: errors.New("connection reset"), want: 4 * time.Second},
+		{attempt: 10, lastErr: errors.New("connection reset"), want: maxRetryDelay},
+		{attempt: 1, lastErr: retryAfter(5 * time.Second), want: 5 * time.Second},
+		{attempt: 1, lastErr: retryAfter(2 * time.Minute), want: maxRetryDelay},
+	}
+	for _, tt := range tests {
+		if got := retryDelay(tt.attempt, tt.lastErr); got != tt.want {
+			t.Errorf("retryDelay(%d, %v) = %s, want %s", tt.attempt, tt.lastErr, got, tt.want)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{value: "120", want: 120 * time.Second, wantOK: true},
+		{value: "0", want: 0, wantOK: true},
+		{value: "Wed, 21 Oct 2015 07:28:00 GMT", want: 0, wantOK: true}, // 過去の日時は待たない
+		{value: "", wantOK: false},
+		{value: "-1", wantOK: false},
+		{value: "soon", wantOK: false},
+	}
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("parseRetryAfter(%q) = %s, %v, want %s, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+// TestRateLimiter はバーストを使い切った後のリクエストが間隔を空けて許可されることを確認する
+func TestRateLimiter(t *testing.T) {
+	l := newRateLimiter(50, 1) // 20ms ごとに 1 回
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 1 回目はバーストで即時、残り 2 回は 20ms ずつ待つ
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Errorf("3 requests took %s, want at least 40ms", elapsed)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := l.Wait(canceled); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait with canceled context = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,142 @@
+package scraper
+
+import (
+	"regexp"
+	"strings"
+)
+
+// GODEBUG 設定のイベント種別
+// 導入（introduced）かどうかは全リリースを通した最初の言及で決まるため、保存時に判定する
+const (
+	GodebugIntroduced     = "introduced"      // 設定が導入され、デフォルト動作が切り替わった
+	GodebugDefaultChanged = "default_changed" // 設定のデフォルト動作が切り替わった
+	GodebugRemoved        = "removed"         // 設定が削除され、旧動作に戻せなくなった
+)
+
+// GodebugChange はリリースノートから抽出した GODEBUG 設定への言及
+type GodebugChange struct {
+	Name        string
+	Version     string
+	Kind        string
+	Value       string // 旧動作に戻すための値（例: "1"）
+	Package     string
+	Description string
+}
+
+var (
+	// GODEBUG=x509sha1=1
+	godebugAssignRegex = regexp.MustCompile(`GODEBUG=([a-z][a-z0-9]+)=([a-z0-9]+)`)
+	// tlsrsakex=1 GODEBUG setting
+	godebugValueBeforeRegex = regexp.MustCompile(`\b([a-z][a-z0-9]+)=([a-z0-9]+)\s+GODEBUG\b`)
+	// GODEBUG setting x509sha1=1 / GODEBUG setting http2client
+	godebugSettingAfterRegex = regexp.MustCompile(`GODEBUG\s+settings?\s+([a-z][a-z0-9]+)(?:=([a-z0-9]+))?`)
+	// httplaxcontentlength GODEBUG setting
+	godebugNameBeforeRegex = regexp.MustCompile(`\b([a-z][a-z0-9]+)\s+GODEBUG\s+setting`)
+
+	godebugRemovedRegex = regexp.MustCompile(`(?i)\b(removed|no longer (?:supported|has any effect|has an effect|available))\b`)
+	// "will be removed in Go 1.NN" のような将来の削除予告（そのリリースでは削除されていない）
+	godebugFutureRemovalRegex = regexp.MustCompile(`(?i)\b(?:will|may|might|could|would|to)\s+(?:\w+\s+)?be\s+removed\b|\bplan(?:s|ned)?\s+to\s+remove\b`)
+)
+
+// 設定名として誤検出しやすい単語
+var godebugStopwords = map[string]bool{
+	"the": true, "new": true, "this": true, "that": true, "using": true, "with": true,
+	"and": true, "or": true, "by": true, "via": true, "setting": true, "settings": true,
+	"was": true, "were": true, "is": true, "are": true, "has": true, "have": true,
+	"can": true, "will": true, "which": true, "for": true, "controls": true, "also": true,
+}
+
+// ExtractGodebugChanges はリリースの各変更の説明文から GODEBUG 設定への言及を抽出する
+// 同じリリース内で複数回言及された設定は 1 件にまとめる
+func ExtractGodebugChanges(release ReleaseInfo) []GodebugChange {
+	var result []GodebugChange
+	index := make(map[string]int)
+
+	for _, change := range release.Changes {
+		for _, sentence := range splitSentences(change.Description) {
+			if !strings.Contains(sentence, "GODEBUG") {
+				continue
+			}
+
+			for _, match := range findGodebugSettings(sentence) {
+				kind := GodebugDefaultChanged
+				if godebugRemovedRegex.MatchString(sentence) && !godebugFutureRemovalRegex.MatchString(sentence) {
+					kind = GodebugRemoved
+				}
+
+				if i, ok := index[match.name]; ok {
+					// 値が後から判明した場合のみ補完
+					if result[i].Value == "" {
+						result[i].Value = match.value
+					}
+					continue
+				}
+
+				index[match.name] = len(result)
+				result = append(result, GodebugChange{
+					Name:        match.name,
+					Version:     release.Version,
+					Kind:        kind,
+					Value:       match.value,
+					Package:     change.Package,
+					Description: strings.TrimSpace(sentence),
+				})
+			}
+		}
+	}
+
+	return result
+}
+
+type godebugMatch struct {
+	name  string
+	value string
+}
+
+func findGodebugSettings(sentence string) []godebugMatch {
+	var matches []godebugMatch
+	seen := make(map[string]bool)
+
+	add := func(name, value string) {
+		if name == "" || len(name) < 3 || godebugStopwords[name] || seen[name] {
+			return
+		}
+		seen[name] = true
+		matches = append(matches, godebugMatch{name: name, value: value})
+	}
+
+	for _, m := range godebugAssignRegex.FindAllStringSubmatch(sentence, -1) {
+		add(m[1], m[2])
+	}
+	for _, m := range godebugValueBeforeRegex.FindAllStringSubmatch(sentence, -1) {
+		add(m[1], m[2])
+	}
+	for _, m := range godebugSettingAfterRegex.FindAllStringSubmatch(sentence, -1) {
+		add(m[1], m[2])
+	}
+	for _, m := range godebugNameBeforeRegex.FindAllStringSubmatch(sentence, -1) {
+		add(m[1], "")
+	}
+
+	return matches
+}
+
+// splitSentences は説明文を文単位に分割する（"e.g." などの略語は考慮しない簡易版）
+func splitSentences(text string) []string {
+	var sentences []string
+	start := 0
+	for i := 0; i < len(text); i++ {
+		if text[i] != '.' {
+			continue
+		}
+		// ピリオドの直後が空白か終端の場合のみ区切る（"crypto/x509.Certificate" などは区切らない）
+		if i+1 == len(text) || text[i+1] == ' ' {
+			sentences = append(sentences, text[start:i+1])
+			start = i + 1
+		}
+	}
+	if start < len(text) {
+		sentences = append(sentences, text[start:])
+	}
+	return sentences
+}
@@ -0,0 +1,39 @@
+package scraper
+
+import "testing"
+
+func TestExtractGodebugChangesRemoval(t *testing.T) {
+	tests := []struct {
+		description string
+		want        string
+	}{
+		{
+			description: "The x509sha1 GODEBUG setting has been removed.",
+			want:        GodebugRemoved,
+		},
+		{
+			description: "The GODEBUG setting x509sha1=1 is no longer supported.",
+			want:        GodebugRemoved,
+		},
+		// 削除の予告はそのリリースでの削除ではない
+		{
+			description: "The GODEBUG setting x509sha1=1 will be removed in Go 1.24.",
+			want:        GodebugDefaultChanged,
+		},
+		{
+			description: "The GODEBUG setting tlsrsakex=1 may be removed in a future release.",
+			want:        GodebugDefaultChanged,
+		},
+	}
+
+	for _, tt := range tests {
+		release := ReleaseInfo{Version: "1.23", Changes: []StandardLibraryChange{{Package: "crypto/x509", Description: tt.description}}}
+		changes := ExtractGodebugChanges(release)
+		if len(changes) != 1 {
+			t.Fatalf("ExtractGodebugChanges(%q) returned %d changes, want 1", tt.description, len(changes))
+		}
+		if changes[0].Kind != tt.want {
+			t.Errorf("ExtractGodebugChanges(%q).Kind = %q, want %q", tt.description, changes[0].Kind, tt.want)
+		}
+	}
+}
@@ -0,0 +1,284 @@
+package scraper
+
+import (
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"go-ver-trace/internal/goversion"
+)
+
+// libraryParser はリリースノートのレイアウトに応じて標準ライブラリの変更を抽出する
+type libraryParser func(rs *ReleaseScraper, ctx *parseContext, doc *goquery.Document) []StandardLibraryChange
+
+// releaseLayout はあるバージョン以降のリリースノートのレイアウト
+type releaseLayout struct {
+	name         string
+	sinceVersion string // このバージョン以降に適用（次のレイアウトの直前まで）
+	parse        libraryParser
+}
+
+// releaseLayouts はバージョン順に並べたレイアウト一覧
+//   - topics: Go 1.0〜1.6。ライブラリ節の h3（1.0 は "The X package"）と "Minor changes" の ul/li
+//   - core: Go 1.7〜1.17。"Core library" 節の話題ごとの h3 と <dl id="pkg"> ブロック
+//   - standard: Go 1.18 以降。"Standard library" 節
+var releaseLayouts = []releaseLayout{
+	{name: "topics", sinceVersion: "1.0", parse: (*ReleaseScraper).extractLegacyLibraryChanges},
+	{name: "core", sinceVersion: "1.7", parse: (*ReleaseScraper).extractCoreLibraryChanges},
+	{name: "standard", sinceVersion: "1.18", parse: (*ReleaseScraper).extractStandardLibraryChangesFromDocument},
+}
+
+// layoutByName は名前からレイアウトを返す
+func layoutByName(name string) (releaseLayout, bool) {
+	for _, layout := range releaseLayouts {
+		if layout.name == name {
+			return layout, true
+		}
+	}
+	return releaseLayout{}, false
+}
+
+// layoutForVersion はバージョンに対応するレイアウトを返す（解析方法の指定があればそれを優先）
+func (rs *ReleaseScraper) layoutForVersion(version string) releaseLayout {
+	if o, ok := rs.overrides[version]; ok && o.Layout != "" {
+		if layout, ok := layoutByName(o.Layout); ok {
+			return layout
+		}
+	}
+
+	for i := len(releaseLayouts) - 1; i > 0; i-- {
+		if goversion.Compare(version, releaseLayouts[i].sinceVersion) >= 0 {
+			return releaseLayouts[i]
+		}
+	}
+	return releaseLayouts[0]
+}
+
+// extractLibraryChanges はバージョンに応じたレイアウトで標準ライブラリの変更を抽出する
+func (rs *ReleaseScraper) extractLibraryChanges(ctx *parseContext, doc *goquery.Document) []StandardLibraryChange {
+	layout := rs.layoutForVersion(ctx.version)
+	ctx.layout = layout.name
+	log.Printf("Go %s: レイアウト %q で標準ライブラリの変更を抽出", ctx.version, layout.name)
+	return layout.parse(rs, ctx, doc)
+}
+
+// isLibraryHeading は標準ライブラリ節の見出しかどうかを判定する
+// （"Standard library"、"Core library"、"Changes to the library"、"Changes to the libraries"、id="library"）
+func isLibraryHeading(heading *goquery.Selection) bool {
+	if id, _ := heading.Attr("id"); id == "library" {
+		return true
+	}
+	text := strings.ToLower(strings.TrimSpace(heading.Text()))
+	return strings.Contains(text, "standard library") || strings.Contains(text, "core library") ||
+		strings.Contains(text, "changes to the librar")
+}
+
+var (
+	// "The archive/zip package" / "The <code>bufio</code> package"
+	thePackageRegex = regexp.MustCompile(`(?i)^the\s+([a-z][a-z0-9]*(?:/[a-z][a-z0-9]*)*)\s+package`)
+	// 一覧形式の節（"Minor changes to the library"、"New packages"）
+	listSectionRegex = regexp.MustCompile(`(?i)(minor changes|new packages|new package)`)
+)
+
+// extractLegacyLibraryChanges は Go 1.0〜1.6 のレイアウトから変更を抽出する
+// ライブラリ節の直下の見出し（h2 の場合は h3、h3#library の場合は h4）ごとに 1 件とし、
+// "Minor changes to the library" などの一覧は li ごとに 1 件とする
+func (rs *ReleaseScraper) extractLegacyLibraryChanges(ctx *parseContext, doc *goquery.Document) []StandardLibraryChange {
+	version := ctx.version
+	var changes []StandardLibraryChange
+
+	doc.Find("h2, h3").EachWithBreak(func(i int, section *goquery.Selection) bool {
+		if !isLibraryHeading(section) {
+			return true
+		}
+
+		stop, topic := "h2", "h3"
+		if section.Is("h3") {
+			stop, topic = "h2, h3", "h4"
+		}
+		log.Printf("Go %s: ライブラリ節を発見: %q", version, strings.TrimSpace(section.Text()))
+
+		var heading *goquery.Selection
+		var body []*goquery.Selection
+		flush := func() {
+			if heading == nil {
+				return
+			}
+			changes = append(changes, rs.legacyTopicChanges(ctx, heading, body)...)
+		}
+
+		section.NextUntil(stop).Each(func(j int, elem *goquery.Selection) {
+			if elem.Is(topic) {
+				flush()
+				heading, body = elem, nil
+				return
+			}
+			if heading == nil {
+				// 最初の見出しより前の本文は節全体の導入なので対象外
+				return
+			}
+			body = append(body, elem)
+		})
+		flush()
+
+		// ライブラリ節は 1 つだけ
+		return false
+	})
+
+	return changes
+}
+
+// extractCoreLibraryChanges は Go 1.7〜1.17 のレイアウトから変更を抽出する
+// <dl> ブロックは Go 1.18 以降と同じ処理で扱い、"Context" のような話題ごとの h3 を追加で抽出する
+func (rs *ReleaseScraper) extractCoreLibraryChanges(ctx *parseContext, doc *goquery.Document) []StandardLibraryChange {
+	changes := rs.extractStandardLibraryChangesFromDocument(ctx, doc)
+
+	doc.Find("h2").EachWithBreak(func(i int, section *goquery.Selection) bool {
+		if !isLibraryHeading(section) {
+			return true
+		}
+
+		section.NextUntil("h2").Filter("h3").Each(func(j int, h3 *goquery.Selection) {
+			text := strings.TrimSpace(h3.Text())
+			// "Minor changes" と "New X package" は extractStandardLibraryChangesFromDocument で抽出済み
+			if listSectionRegex.MatchString(text) || rs.extractPackageNameFromH3(text) != "" {
+				return
+			}
+
+			var body []*goquery.Selection
+			h3.NextUntil("h2, h3").Each(func(k int, elem *goquery.Selection) {
+				body = append(body, elem)
+			})
+			changes = append(changes, rs.legacyTopicChanges(ctx, h3, body)...)
+		})
+		return false
+	})
+
+	return changes
+}
+
+// legacyTopicChanges は見出し 1 つ分の本文から変更を組み立てる
+func (rs *ReleaseScraper) legacyTopicChanges(ctx *parseContext, heading *goquery.Selection, body []*goquery.Selection) []StandardLibraryChange {
+	version := ctx.version
+	headingText := strings.Join(strings.Fields(heading.Text()), " ")
+	var changes []StandardLibraryChange
+
+	// 一覧形式の節は li ごとにパッケージを判定する
+	if listSectionRegex.MatchString(headingText) {
+		for _, elem := range body {
+			if !elem.Is("ul, ol") {
+				continue
+			}
+			elem.ChildrenFiltered("li").Each(func(i int, li *goquery.Selection) {
+				packageName := rs.legacyPackageFromElement(li)
+				if packageName == "" {
+					ctx.report(DiagnosticUnparsedHeading, MakeExcerpt(normalizeSpace(li.Text())), "li ("+headingText+")")
+					return
+				}
+				var desc description
+				desc.add(li)
+				change := rs.newChange(packageName, desc)
+				change.Subheading = headingText
+				changes = append(changes, change)
+				log.Printf("Go %s: パッケージ %s の変更を抽出 (li)", version, packageName)
+			})
+		}
+		if len(changes) > 0 {
+			return changes
+		}
+	}
+
+	// 見出しごとの節（Go 1.0 の "The X package"、1.1〜1.6 の話題ごとの h3）
+	var desc description
+	for _, elem := range body {
+		if elem.Is("p, ul, ol, pre, dl, blockquote, table") {
+			desc.add(elem)
+		}
+	}
+	if desc.empty() {
+		return nil
+	}
+
+	packageName := rs.legacyPackageFromHeading(heading)
+	if packageName == "" {
+		// 見出しにパッケージ名がない場合は本文最初のパッケージへのリンクを使う
+		for _, elem := range body {
+			if packageName = rs.legacyPackageFromElement(elem); packageName != "" {
+				break
+			}
+		}
+	}
+	if packageName == "" {
+		ctx.report(DiagnosticUnparsedHeading, headingText, goquery.NodeName(heading))
+		return nil
+	}
+
+	change := rs.newChange(packageName, desc)
+	change.Subheading = headingText
+	log.Printf("Go %s: パッケージ %s の変更を抽出 (%s)", version, packageName, goquery.NodeName(heading))
+	return append(changes, change)
+}
+
+// legacyPackageFromHeading は "The archive/zip package" や <code>bufio.Scanner</code> を含む見出しからパッケージ名を取り出す
+func (rs *ReleaseScraper) legacyPackageFromHeading(heading *goquery.Selection) string {
+	text := strings.Join(strings.Fields(heading.Text()), " ")
+	if m := thePackageRegex.FindStringSubmatch(text); m != nil && rs.isValidPackageName(m[1]) {
+		return m[1]
+	}
+	if pkg := rs.extractPackageNameFromH3(text); pkg != "" {
+		return pkg
+	}
+
+	var packageName string
+	heading.Find("code").EachWithBreak(func(i int, code *goquery.Selection) bool {
+		packageName = rs.legacyPackageFromSymbol(code.Text())
+		return packageName == ""
+	})
+	return packageName
+}
+
+// legacyPackageFromElement は要素内で最初に現れるパッケージドキュメントへのリンクからパッケージ名を取り出す
+func (rs *ReleaseScraper) legacyPackageFromElement(elem *goquery.Selection) string {
+	var packageName string
+	elem.Find("a[href]").EachWithBreak(func(i int, link *goquery.Selection) bool {
+		href, _ := link.Attr("href")
+		packageName = rs.legacyPackageFromHref(href)
+		return packageName == ""
+	})
+	if packageName != "" {
+		return packageName
+	}
+
+	elem.Find("code").EachWithBreak(func(i int, code *goquery.Selection) bool {
+		packageName = rs.legacyPackageFromSymbol(code.Text())
+		return packageName == ""
+	})
+	return packageName
+}
+
+// legacyPackageFromSymbol は "bufio.Scanner" や "net/http" から既知のパッケージ名を取り出す
+func (rs *ReleaseScraper) legacyPackageFromSymbol(symbol string) string {
+	symbol = strings.TrimSpace(symbol)
+	if i := strings.Index(symbol, "."); i > 0 {
+		symbol = symbol[:i]
+	}
+	if rs.isValidPackageName(symbol) && rs.isKnownStandardPackage(symbol) {
+		return symbol
+	}
+	return ""
+}
+
+// legacyPackageFromHref は "/pkg/bufio/#Scanner" や "https://golang.org/pkg/net/http/" からパッケージ名を取り出す
+func (rs *ReleaseScraper) legacyPackageFromHref(href string) string {
+	i := strings.Index(href, "/pkg/")
+	if i < 0 {
+		return ""
+	}
+	path := href[i+len("/pkg/"):]
+	if j := strings.IndexAny(path, "#?"); j >= 0 {
+		path = path[:j]
+	}
+	return rs.extractPackageNameFromHref("/pkg/" + path)
+}
@@ -0,0 +1,129 @@
+package scraper
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// wantChange は抽出される変更のうち、テストで確認する項目
+type wantChange struct {
+	pkg        string
+	subheading string
+	excerpt    string // 抜粋に含まれる文字列
+}
+
+// TestExtractLibraryChangesLayouts はレイアウトごとのリリースノートから標準ライブラリの変更を抽出できることを確認する
+func TestExtractLibraryChangesLayouts(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		version string
+		layout  string
+		want    []wantChange
+	}{
+		{
+			name:    "topics",
+			fixture: "legacy_topics.html",
+			version: "1.4",
+			layout:  "topics",
+			want: []wantChange{
+				{pkg: "syscall", subheading: "The syscall package", excerpt: "is now frozen"},
+				{pkg: "compress/flate", subheading: "Compression", excerpt: "reset a Writer"},
+				{pkg: "bufio", subheading: "Minor changes to the library", excerpt: "final empty token"},
+				{pkg: "net/http", subheading: "Minor changes to the library", excerpt: "Request.BasicAuth"},
+			},
+		},
+		{
+			name:    "core",
+			fixture: "legacy_core.html",
+			version: "1.7",
+			layout:  "core",
+			want: []wantChange{
+				{pkg: "bytes", subheading: "Minor changes to the library", excerpt: "ContainsAny"},
+				{pkg: "os/exec", subheading: "Minor changes to the library", excerpt: "CommandContext"},
+				// dd 直下の地の文とインライン要素は 1 つの説明として残す
+				{pkg: "strings", subheading: "Minor changes to the library", excerpt: "The new Reader.Size method returns the original length"},
+				{pkg: "context", subheading: "Context", excerpt: "into the standard library"},
+			},
+		},
+		{
+			name:    "standard",
+			fixture: "legacy_standard.html",
+			version: "1.21",
+			layout:  "standard",
+			want: []wantChange{
+				{pkg: "log/slog", subheading: "New log/slog package", excerpt: "structured logging"},
+				{pkg: "bytes", subheading: "Minor changes to the library", excerpt: "AvailableBuffer"},
+				{pkg: "context", subheading: "Minor changes to the library", excerpt: "WithoutCancel"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := loadFixture(t, tt.fixture)
+			rs := NewReleaseScraper()
+			ctx := &parseContext{rs: rs, version: tt.version}
+
+			changes := rs.extractLibraryChanges(ctx, doc)
+
+			if ctx.layout != tt.layout {
+				t.Errorf("layout = %q, want %q", ctx.layout, tt.layout)
+			}
+			if len(changes) != len(tt.want) {
+				var got []string
+				for _, c := range changes {
+					got = append(got, c.Package)
+				}
+				t.Fatalf("extracted %d changes %v, want %d", len(changes), got, len(tt.want))
+			}
+			for i, want := range tt.want {
+				got := changes[i]
+				if got.Package != want.pkg {
+					t.Errorf("changes[%d].Package = %q, want %q", i, got.Package, want.pkg)
+				}
+				if got.Subheading != want.subheading {
+					t.Errorf("changes[%d].Subheading = %q, want %q", i, got.Subheading, want.subheading)
+				}
+				if !strings.Contains(got.Excerpt, want.excerpt) {
+					t.Errorf("changes[%d].Excerpt = %q, want it to contain %q", i, got.Excerpt, want.excerpt)
+				}
+			}
+		})
+	}
+}
+
+// TestLayoutForVersion はバージョンごとに適用するレイアウトを確認する
+func TestLayoutForVersion(t *testing.T) {
+	rs := NewReleaseScraper()
+	for version, want := range map[string]string{
+		"1.0":  "topics",
+		"1.6":  "topics",
+		"1.7":  "core",
+		"1.17": "core",
+		"1.18": "standard",
+		"1.24": "standard",
+	} {
+		if got := rs.layoutForVersion(version).name; got != want {
+			t.Errorf("layoutForVersion(%q) = %q, want %q", version, got, want)
+		}
+	}
+}
+
+func loadFixture(t *testing.T, name string) *goquery.Document {
+	t.Helper()
+	f, err := os.Open(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	doc, err := goquery.NewDocumentFromReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
@@ -0,0 +1,87 @@
+package scraper
+
+import (
+	"net/url"
+	"strings"
+)
+
+// リンク種別
+const (
+	LinkKindDoc      = "doc"      // pkg.go.dev / go.dev/pkg のシンボル・パッケージドキュメント
+	LinkKindIssue    = "issue"    // Go の issue トラッカー
+	LinkKindCL       = "cl"       // Gerrit の変更リスト
+	LinkKindProposal = "proposal" // 設計ドキュメント・proposal
+	LinkKindExternal = "external" // 上記以外
+)
+
+// ChangeLink は変更の説明文に含まれていた外部参照
+type ChangeLink struct {
+	URL  string
+	Text string
+	Kind string
+}
+
+// ClassifyLink は URL とリンクテキストから参照の種別を判定する
+func ClassifyLink(rawURL, text string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return LinkKindExternal
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+	path := u.Path
+
+	switch host {
+	case "pkg.go.dev":
+		return LinkKindDoc
+	case "go-review.googlesource.com":
+		return LinkKindCL
+	case "github.com":
+		if strings.HasPrefix(path, "/golang/proposal") {
+			return LinkKindProposal
+		}
+		if strings.HasPrefix(path, "/golang/go/issues/") {
+			return issueOrProposal(text)
+		}
+	case "go.googlesource.com":
+		if strings.HasPrefix(path, "/proposal") {
+			return LinkKindProposal
+		}
+	case "go.dev", "golang.org", "tip.golang.org":
+		switch {
+		case strings.HasPrefix(path, "/pkg/"):
+			return LinkKindDoc
+		case strings.HasPrefix(path, "/issue/") || strings.HasPrefix(path, "/issues/"):
+			return issueOrProposal(text)
+		case strings.HasPrefix(path, "/cl/"):
+			return LinkKindCL
+		case strings.HasPrefix(path, "/design/") || strings.HasPrefix(path, "/s/proposal"):
+			return LinkKindProposal
+		}
+	}
+
+	return LinkKindExternal
+}
+
+// proposal は issue として管理されているため、リンクテキストで判別する
+func issueOrProposal(text string) string {
+	if strings.Contains(strings.ToLower(text), "proposal") {
+		return LinkKindProposal
+	}
+	return LinkKindIssue
+}
+
+// NewChangeLink は URL を正規化して種別付きのリンクを作成する（不正な URL の場合は false）
+func NewChangeLink(rawURL, text string) (ChangeLink, bool) {
+	href := sanitizeHref(rawURL)
+	if href == "" {
+		return ChangeLink{}, false
+	}
+
+	text = strings.Join(strings.Fields(text), " ")
+	return ChangeLink{
+		URL:  href,
+		Text: text,
+		Kind: ClassifyLink(href, text),
+	}, true
+}
@@ -0,0 +1,395 @@
+package scraper
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"html"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"go-ver-trace/internal/goversion"
+)
+
+// Markdown のリリースノートは go.dev の HTML の生成元
+//   - x/website の _content/doc/go1.NN.md（リリース済みのバージョン）
+//   - Go リポジトリの doc/next/*.md（開発中のバージョン。6-stdlib/99-minor/<パッケージ>/*.md が 1 件ずつの変更）
+//
+// 見出しにパッケージドキュメントへのリンクがある節（"### [`net/http`](/pkg/net/http/)"）を
+// パッケージの節とし、箇条書きの項目・段落ごとに 1 件の変更とする
+
+var (
+	// "## Standard library {#library}"
+	markdownHeadingRegex = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*(?:\{#[^}]*\})?\s*$`)
+	// "- 項目" / "* 項目" / "1. 項目"
+	markdownListItemRegex = regexp.MustCompile(`^(?:[-*+]|\d+[.)])\s+`)
+	markdownLinkRegex     = regexp.MustCompile(`\[((?:[^\[\]]|\[[^\]]*\])*)\]\(([^)\s]+)\)`) // ラベルは "Null[T]" のような 1 段の角括弧を含められる
+	markdownCodeRegex     = regexp.MustCompile("`([^`]+)`")
+	markdownFileRegex     = regexp.MustCompile(`^go(1\.\d+)\.md$`)
+	// src/internal/goversion/goversion.go の "const Version = 25"
+	goversionConstRegex = regexp.MustCompile(`(?m)^const Version = (\d+)`)
+)
+
+// LoadMarkdownReleaseNotes は dir にある Markdown のリリースノートを解析する
+// dir には x/website のチェックアウト（または _content/doc）、Go リポジトリのチェックアウト（doc/next）を指定できる
+func (rs *ReleaseScraper) LoadMarkdownReleaseNotes(dir string) ([]ReleaseInfo, error) {
+	var releases []ReleaseInfo
+
+	docDir := dir
+	if info, err := os.Stat(filepath.Join(dir, "_content", "doc")); err == nil && info.IsDir() {
+		docDir = filepath.Join(dir, "_content", "doc")
+	}
+	entries, err := os.ReadDir(docDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", docDir, err)
+	}
+	for _, entry := range entries {
+		m := markdownFileRegex.FindStringSubmatch(entry.Name())
+		if m == nil {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(docDir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", entry.Name(), err)
+		}
+		release := rs.ParseMarkdownReleaseNotes(m[1], data)
+		release.ReleaseDate = rs.getActualReleaseDate(m[1], nil)
+		releases = append(releases, release)
+	}
+
+	// Go リポジトリの doc/next は開発中のバージョンのリリースノート
+	nextDir := filepath.Join(dir, "doc", "next")
+	if info, err := os.Stat(nextDir); err == nil && info.IsDir() {
+		version, err := developmentVersion(dir)
+		if err != nil {
+			return nil, err
+		}
+		data, err := concatNextFragments(nextDir)
+		if err != nil {
+			return nil, err
+		}
+		// 開発中のバージョンはプレリリースとして扱う（URL は tip の草稿、リリース日は取り込んだ日）
+		release := rs.ParseMarkdownReleaseNotes(version, data)
+		release.ReleaseDate = rs.getActualReleaseDate(version, nil)
+		releases = append(releases, release)
+	}
+
+	if len(releases) == 0 {
+		return nil, fmt.Errorf("no markdown release notes found in %s", dir)
+	}
+	sort.Slice(releases, func(i, j int) bool {
+		return goversion.Compare(releases[i].Version, releases[j].Version) < 0
+	})
+	return releases, nil
+}
+
+// developmentVersion は Go リポジトリのチェックアウトが指す開発中のバージョンをプレリリースの表記で返す
+// リリースブランチの VERSION ファイル（"go1.26rc1"）があればそれを使い、
+// なければ src/internal/goversion のマイナーバージョンに rc0（最初の RC より前の tip）を付ける
+func developmentVersion(repoDir string) (string, error) {
+	if data, err := os.ReadFile(filepath.Join(repoDir, "VERSION")); err == nil {
+		line, _, _ := strings.Cut(string(data), "\n")
+		if v, err := goversion.Parse(strings.TrimSpace(line)); err == nil && v.Pre != "" {
+			return v.String(), nil
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(repoDir, "src", "internal", "goversion", "goversion.go"))
+	if err != nil {
+		return "", fmt.Errorf("failed to determine development version: %w", err)
+	}
+	m := goversionConstRegex.FindSubmatch(data)
+	if m == nil {
+		return "", fmt.Errorf("failed to determine development version: Version constant not found")
+	}
+	return "1." + string(m[1]) + "rc0", nil
+}
+
+// concatNextFragments は doc/next の断片をパス順に連結して 1 つの Markdown にする
+// 6-stdlib/99-minor/<パッケージ>/ の断片にはパッケージの見出しを補う（go.dev の relnote ツールと同じ）
+func concatNextFragments(nextDir string) ([]byte, error) {
+	minorDir := filepath.Join("6-stdlib", "99-minor")
+	var buf bytes.Buffer
+	lastPackage := ""
+
+	err := filepath.WalkDir(nextDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".md" {
+			return nil
+		}
+		rel, err := filepath.Rel(nextDir, path)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		if pkgDir, ok := strings.CutPrefix(filepath.Dir(rel), minorDir+string(filepath.Separator)); ok {
+			pkg := filepath.ToSlash(pkgDir)
+			if pkg != lastPackage {
+				fmt.Fprintf(&buf, "\n#### [`%s`](/pkg/%s/)\n\n", pkg, pkg)
+				lastPackage = pkg
+			}
+		}
+		buf.Write(data)
+		buf.WriteString("\n\n")
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", nextDir, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// markdownBlock は段落・箇条書きの項目・コードブロックのいずれか
+type markdownBlock struct {
+	kind  string // p / li / pre
+	lines []string
+}
+
+// ParseMarkdownReleaseNotes は 1 バージョン分の Markdown のリリースノートを解析する
+func (rs *ReleaseScraper) ParseMarkdownReleaseNotes(version string, data []byte) ReleaseInfo {
+	pctx := &parseContext{rs: rs, version: version, layout: "markdown"}
+	release := ReleaseInfo{
+		Version: version,
+		URL:     rs.GetVersionDocumentURL(version),
+	}
+
+	var changes []StandardLibraryChange
+	area := ""
+	subheading := ""
+	packageName := "" // パッケージの節の場合のパッケージ名
+	var sectionDesc description
+
+	// 標準ライブラリ以外の領域は HTML と同じく h3 ごとに 1 件にまとめる
+	flushSection := func() {
+		if area == "" || area == AreaStdlib || sectionDesc.empty() {
+			sectionDesc = description{}
+			return
+		}
+		change := rs.newChange(areaPackage(area, subheading), sectionDesc)
+		change.Area = area
+		change.Subheading = subheading
+		changes = append(changes, change)
+		sectionDesc = description{}
+	}
+
+	addBlock := func(block markdownBlock) {
+		sel := renderMarkdownBlock(block)
+		if sel == nil || area == "" {
+			return
+		}
+		if area != AreaStdlib {
+			sectionDesc.add(sel)
+			return
+		}
+
+		// コードブロックは直前の変更の説明に含める
+		if block.kind == "pre" {
+			if n := len(changes); n > 0 && changes[n-1].Area == AreaStdlib {
+				var desc description
+				desc.add(sel)
+				changes[n-1].DescriptionHTML += "\n" + desc.HTML()
+			}
+			return
+		}
+
+		pkg := packageName
+		if pkg == "" {
+			// パッケージの節でない場合（"### New math/rand/v2 package" など）は見出し・本文から判定する
+			if pkg = rs.extractPackageNameFromH3(subheading); pkg == "" {
+				pkg = rs.legacyPackageFromElement(sel)
+			}
+		}
+		if pkg == "" {
+			if subheading != "" {
+				pctx.report(DiagnosticUnparsedHeading, MakeExcerpt(normalizeSpace(sel.Text())), "markdown ("+subheading+")")
+			}
+			return
+		}
+
+		var desc description
+		desc.add(sel)
+		if desc.empty() {
+			return
+		}
+		change := rs.newChange(pkg, desc)
+		change.Area = AreaStdlib
+		if packageName == "" {
+			change.Subheading = subheading
+		}
+		changes = append(changes, change)
+	}
+
+	var block *markdownBlock
+	flushBlock := func() {
+		if block != nil {
+			addBlock(*block)
+			block = nil
+		}
+	}
+
+	inFence := false
+	inComment := false
+	listIndent := 0 // 箇条書きの最初の項目の字下げ（これより深い項目は入れ子として直前の項目に含める）
+	scanner := bufio.NewScanner(bytes.NewReader(stripFrontMatter(data)))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		if inFence {
+			if strings.HasPrefix(trimmed, "```") {
+				inFence = false
+				flushBlock()
+				continue
+			}
+			block.lines = append(block.lines, line)
+			continue
+		}
+		if inComment {
+			if strings.Contains(trimmed, "-->") {
+				inComment = false
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(trimmed, "<!--"):
+			inComment = !strings.Contains(trimmed, "-->")
+		case strings.HasPrefix(trimmed, "```"):
+			flushBlock()
+			inFence = true
+			block = &markdownBlock{kind: "pre"}
+		case trimmed == "":
+			flushBlock()
+		case markdownHeadingRegex.MatchString(trimmed) && strings.HasPrefix(line, "#"):
+			flushBlock()
+			m := markdownHeadingRegex.FindStringSubmatch(trimmed)
+			level, text := len(m[1]), m[2]
+			switch {
+			case level <= 2:
+				flushSection()
+				area = areaForHeading(markdownPlainText(text))
+				subheading, packageName = "", ""
+			case markdownPackageFromHeading(rs, text) != "":
+				packageName = markdownPackageFromHeading(rs, text)
+			case level == 3:
+				flushSection()
+				subheading, packageName = markdownPlainText(text), ""
+			}
+		case markdownListItemRegex.MatchString(trimmed) && !isNestedListItem(block, line, listIndent):
+			if block == nil || block.kind != "li" {
+				listIndent = len(line) - len(strings.TrimLeft(line, " "))
+			}
+			flushBlock()
+			block = &markdownBlock{kind: "li", lines: []string{markdownListItemRegex.ReplaceAllString(trimmed, "")}}
+		default:
+			if block == nil {
+				block = &markdownBlock{kind: "p"}
+			}
+			block.lines = append(block.lines, trimmed)
+		}
+	}
+	flushBlock()
+	flushSection()
+
+	release.Changes = changes
+	tagExperiments(release.Changes)
+	tagPlatforms(release.Changes)
+	release.Godebugs = ExtractGodebugChanges(release)
+	release.Diagnostics = pctx.diagnostics
+
+	log.Printf("Go %s: Markdown から抽出した変更数 %d", version, len(changes))
+	return release
+}
+
+// isNestedListItem は箇条書きの項目が直前の項目の入れ子かどうかを判定する
+func isNestedListItem(block *markdownBlock, line string, listIndent int) bool {
+	if block == nil || block.kind != "li" {
+		return false
+	}
+	return len(line)-len(strings.TrimLeft(line, " ")) > listIndent+1
+}
+
+// markdownPackageFromHeading は "[`net/http`](/pkg/net/http/)" のような見出しからパッケージ名を取り出す
+func markdownPackageFromHeading(rs *ReleaseScraper, text string) string {
+	for _, m := range markdownLinkRegex.FindAllStringSubmatch(text, -1) {
+		if pkg := rs.legacyPackageFromHref(m[2]); pkg != "" {
+			return pkg
+		}
+	}
+	return ""
+}
+
+// stripFrontMatter は先頭の "---" で囲まれたメタデータを取り除く
+func stripFrontMatter(data []byte) []byte {
+	rest, ok := bytes.CutPrefix(data, []byte("---\n"))
+	if !ok {
+		return data
+	}
+	if i := bytes.Index(rest, []byte("\n---\n")); i >= 0 {
+		return rest[i+len("\n---\n"):]
+	}
+	return data
+}
+
+// markdownPlainText は見出しなどのインライン記法を取り除いたテキストを返す
+func markdownPlainText(text string) string {
+	text = markdownLinkRegex.ReplaceAllString(text, "$1")
+	return strings.TrimSpace(strings.ReplaceAll(text, "`", ""))
+}
+
+// renderMarkdownBlock はブロックを HTML にして、説明文の抽出に使う要素を返す
+func renderMarkdownBlock(block markdownBlock) *goquery.Selection {
+	var content string
+	if block.kind == "pre" {
+		content = html.EscapeString(strings.Join(block.lines, "\n"))
+	} else {
+		content = renderMarkdownInline(strings.Join(block.lines, " "))
+	}
+	if strings.TrimSpace(content) == "" {
+		return nil
+	}
+
+	tag := block.kind
+	source := fmt.Sprintf("<%s>%s</%s>", tag, content, tag)
+	if tag == "li" {
+		source = "<ul>" + source + "</ul>"
+	}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(source))
+	if err != nil {
+		return nil
+	}
+	return doc.Find(tag).First()
+}
+
+// renderMarkdownInline はリンクとコードスパンを HTML にする（それ以外の記法はそのまま残す）
+func renderMarkdownInline(text string) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range markdownLinkRegex.FindAllStringSubmatchIndex(text, -1) {
+		b.WriteString(renderMarkdownCode(text[last:m[0]]))
+		label, href := text[m[2]:m[3]], text[m[4]:m[5]]
+		fmt.Fprintf(&b, `<a href="%s">%s</a>`, html.EscapeString(href), renderMarkdownCode(label))
+		last = m[1]
+	}
+	b.WriteString(renderMarkdownCode(text[last:]))
+	return b.String()
+}
+
+func renderMarkdownCode(text string) string {
+	escaped := html.EscapeString(text)
+	return markdownCodeRegex.ReplaceAllString(escaped, "<code>$1</code>")
+}
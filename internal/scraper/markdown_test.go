@@ -0,0 +1,132 @@
+package scraper
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// wantMarkdownChange は Markdown から抽出される変更のうち、テストで確認する項目
+type wantMarkdownChange struct {
+	area       string
+	pkg        string
+	subheading string
+	excerpt    string // 抜粋に含まれる文字列
+}
+
+// TestLoadMarkdownReleaseNotes は x/website の go1.NN.md と Go リポジトリの doc/next から変更を抽出できることを確認する
+func TestLoadMarkdownReleaseNotes(t *testing.T) {
+	tests := []struct {
+		name       string
+		dir        string
+		version    string
+		prerelease bool
+		want       []wantMarkdownChange
+	}{
+		{
+			name:    "website",
+			dir:     "testdata/markdown/website",
+			version: "1.22",
+			want: []wantMarkdownChange{
+				// 標準ライブラリ以外の領域は h3 ごとに 1 件（箇条書きも含める）
+				{area: AreaLanguage, pkg: "language", excerpt: "were created once and updated by each iteration"},
+				{area: AreaTools, pkg: "cmd/go", subheading: "Go command", excerpt: "can now use a vendor directory"},
+				// パッケージの節でない h3 は見出しからパッケージ名を判定する
+				{area: AreaStdlib, pkg: "math/rand/v2", subheading: "New math/rand/v2 package", excerpt: "the first “v2” package"},
+				// リンクのラベルの角括弧はそのまま残し、Markdown の記法は抜粋に残さない
+				{area: AreaStdlib, pkg: "database/sql", excerpt: "The new Null[T] type provide"},
+				// パッケージの節では段落ごとに 1 件
+				{area: AreaStdlib, pkg: "slices", excerpt: "concatenates multiple slices"},
+				{area: AreaStdlib, pkg: "slices", excerpt: "now zero the elements"},
+				{area: AreaPorts, pkg: "ports/darwin", subheading: "Darwin", excerpt: "position-independent executables"},
+			},
+		},
+		{
+			name:       "doc/next",
+			dir:        "testdata/markdown/goroot",
+			version:    "1.26rc1", // リリースブランチの VERSION ファイル
+			prerelease: true,
+			want: []wantMarkdownChange{
+				{area: AreaStdlib, pkg: "net/http", excerpt: "StrictMaxConcurrentRequests"},
+				{area: AreaStdlib, pkg: "os", excerpt: "asynchronous I/O"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			releases, err := NewReleaseScraper().LoadMarkdownReleaseNotes(tt.dir)
+			if err != nil {
+				t.Fatalf("LoadMarkdownReleaseNotes: %v", err)
+			}
+			if len(releases) != 1 {
+				t.Fatalf("got %d releases, want 1", len(releases))
+			}
+			release := releases[0]
+			if release.Version != tt.version || release.Prerelease() != tt.prerelease {
+				t.Errorf("version = %s (prerelease %v), want %s (prerelease %v)", release.Version, release.Prerelease(), tt.version, tt.prerelease)
+			}
+			if len(release.Diagnostics) != 0 {
+				t.Errorf("diagnostics = %+v, want none", release.Diagnostics)
+			}
+
+			if len(release.Changes) != len(tt.want) {
+				var got []string
+				for _, c := range release.Changes {
+					got = append(got, c.Package)
+				}
+				t.Fatalf("extracted %d changes %v, want %d", len(release.Changes), got, len(tt.want))
+			}
+			for i, want := range tt.want {
+				got := release.Changes[i]
+				if got.Area != want.area || got.Package != want.pkg || got.Subheading != want.subheading {
+					t.Errorf("changes[%d] = %s %s (%q), want %s %s (%q)", i, got.Area, got.Package, got.Subheading, want.area, want.pkg, want.subheading)
+				}
+				if !strings.Contains(got.Excerpt, want.excerpt) {
+					t.Errorf("changes[%d].Excerpt = %q, want it to contain %q", i, got.Excerpt, want.excerpt)
+				}
+			}
+		})
+	}
+}
+
+// TestParseMarkdownCodeBlock はコードブロックを直前の変更の説明に含め、別の変更にしないことを確認する
+func TestParseMarkdownCodeBlock(t *testing.T) {
+	release := NewReleaseScraper().ParseMarkdownReleaseNotes("1.22", []byte("## Standard library\n\n"+
+		"#### [`slices`](/pkg/slices/)\n\n"+
+		"Functions that shrink the size of a slice now zero the elements between the new length and the old length.\n\n"+
+		"```\ns := slices.Delete(s, 1, 2)\n```\n"))
+
+	if len(release.Changes) != 1 {
+		t.Fatalf("got %d changes, want 1", len(release.Changes))
+	}
+	if html := release.Changes[0].DescriptionHTML; !strings.Contains(html, "<pre>s := slices.Delete(s, 1, 2)</pre>") {
+		t.Errorf("DescriptionHTML = %q, want it to contain the code block", html)
+	}
+}
+
+// TestDevelopmentVersion は VERSION ファイルのプレリリース表記を使い、ない場合は goversion の定数に rc0 を付けることを確認する
+func TestDevelopmentVersion(t *testing.T) {
+	version, err := developmentVersion("testdata/markdown/goroot")
+	if err != nil || version != "1.26rc1" {
+		t.Errorf("developmentVersion with VERSION = %q, %v, want 1.26rc1", version, err)
+	}
+
+	dir := t.TempDir()
+	if _, err := developmentVersion(dir); err == nil {
+		t.Error("developmentVersion succeeded without VERSION or goversion.go")
+	}
+
+	src := filepath.Join(dir, "src", "internal", "goversion")
+	if err := os.MkdirAll(src, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "goversion.go"), []byte("package goversion\n\nconst Version = 27\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	version, err = developmentVersion(dir)
+	if err != nil || version != "1.27rc0" {
+		t.Errorf("developmentVersion without VERSION = %q, %v, want 1.27rc0", version, err)
+	}
+}
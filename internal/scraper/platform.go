@@ -0,0 +1,159 @@
+package scraper
+
+import (
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"go-ver-trace/internal/platform"
+)
+
+// リリースノートでの表記と GOOS の対応（小文字で比較）
+var goosAliases = map[string]string{
+	"windows":       "windows",
+	"linux":         "linux",
+	"macos":         "darwin",
+	"mac os x":      "darwin",
+	"os x":          "darwin",
+	"darwin":        "darwin",
+	"ios":           "ios",
+	"android":       "android",
+	"freebsd":       "freebsd",
+	"openbsd":       "openbsd",
+	"netbsd":        "netbsd",
+	"dragonfly":     "dragonfly",
+	"dragonfly bsd": "dragonfly",
+	"solaris":       "solaris",
+	"illumos":       "illumos",
+	"aix":           "aix",
+	"plan 9":        "plan9",
+	"plan9":         "plan9",
+	"wasip1":        "wasip1",
+	"wasi":          "wasip1",
+}
+
+// リリースノートでの表記と GOARCH の対応（小文字で比較）
+var goarchAliases = map[string]string{
+	"amd64":       "amd64",
+	"x86-64":      "amd64",
+	"386":         "386",
+	"arm64":       "arm64",
+	"arm":         "arm",
+	"ppc64":       "ppc64",
+	"ppc64le":     "ppc64le",
+	"s390x":       "s390x",
+	"riscv64":     "riscv64",
+	"risc-v":      "riscv64",
+	"loong64":     "loong64",
+	"loongarch":   "loong64",
+	"loongarch64": "loong64",
+	"mips":        "mips",
+	"mipsle":      "mipsle",
+	"mips64":      "mips64",
+	"mips64le":    "mips64le",
+	"wasm":        "wasm",
+	"webassembly": "wasm",
+}
+
+var (
+	// linux/arm64, js/wasm, windows-386（ビルダー名）のような GOOS/GOARCH の組
+	platformPairRegex = regexp.MustCompile(`\b(` + strings.Join(platform.KnownGOOS, "|") + `)[/-](` + strings.Join(platform.KnownGOARCH, "|") + `)\b`)
+	// GOOS=windows / GOARCH=arm64 の指定
+	goosAssignRegex   = regexp.MustCompile(`\bGOOS=([a-z0-9]+)`)
+	goarchAssignRegex = regexp.MustCompile(`\bGOARCH=([a-z0-9]+)`)
+
+	goosWordRegex   = aliasRegex(goosAliases)
+	goarchWordRegex = aliasRegex(goarchAliases)
+
+	unixRegex = regexp.MustCompile(`(?i)\bunix\b`)
+)
+
+// "Unix" と書かれた変更が対象とする GOOS
+var unixGOOS = []string{"aix", "android", "darwin", "dragonfly", "freebsd", "illumos", "ios", "linux", "netbsd", "openbsd", "solaris"}
+
+// aliasRegex は表記のいずれかに単語単位で一致する正規表現を作る（長い表記を優先）
+func aliasRegex(aliases map[string]string) *regexp.Regexp {
+	var names []string
+	for name := range aliases {
+		names = append(names, regexp.QuoteMeta(name))
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if len(names[i]) != len(names[j]) {
+			return len(names[i]) > len(names[j])
+		}
+		return names[i] < names[j]
+	})
+	return regexp.MustCompile(`(?i)(?:^|[^a-z0-9/._-])(` + strings.Join(names, "|") + `)(?:$|[^a-z0-9/_-])`)
+}
+
+// DetectPlatforms はパッケージ名・見出し・説明文から変更が対象とするプラットフォームを判定する
+// プラットフォームへの言及がない変更は全プラットフォーム共通として nil を返す
+func DetectPlatforms(packageName, heading, text string) []platform.Platform {
+	seen := make(map[platform.Platform]bool)
+	var platforms []platform.Platform
+	add := func(p platform.Platform) {
+		if (p.GOOS == "" && p.GOARCH == "") || seen[p] {
+			return
+		}
+		seen[p] = true
+		platforms = append(platforms, p)
+	}
+
+	// パッケージパスの要素（syscall/js, internal/syscall/windows など）
+	for _, elem := range strings.Split(packageName, "/") {
+		switch {
+		case elem == "js":
+			add(platform.Platform{GOOS: "js", GOARCH: "wasm"})
+		case slices.Contains(platform.KnownGOOS, elem):
+			add(platform.Platform{GOOS: elem})
+		}
+	}
+
+	for _, s := range []string{heading, text} {
+		// 組で書かれた表記を先に取り出し、単語単位の判定で重複しないように除く
+		for _, m := range platformPairRegex.FindAllStringSubmatch(s, -1) {
+			add(platform.Platform{GOOS: m[1], GOARCH: m[2]})
+		}
+		s = platformPairRegex.ReplaceAllString(s, " ")
+
+		for _, m := range goosAssignRegex.FindAllStringSubmatch(s, -1) {
+			if slices.Contains(platform.KnownGOOS, m[1]) {
+				add(platform.Platform{GOOS: m[1]})
+			}
+		}
+		for _, m := range goarchAssignRegex.FindAllStringSubmatch(s, -1) {
+			if slices.Contains(platform.KnownGOARCH, m[1]) {
+				add(platform.Platform{GOARCH: m[1]})
+			}
+		}
+		s = goosAssignRegex.ReplaceAllString(s, " ")
+		s = goarchAssignRegex.ReplaceAllString(s, " ")
+
+		for _, m := range goosWordRegex.FindAllStringSubmatch(s, -1) {
+			add(platform.Platform{GOOS: goosAliases[strings.ToLower(m[1])]})
+		}
+		for _, m := range goarchWordRegex.FindAllStringSubmatch(s, -1) {
+			add(platform.Platform{GOARCH: goarchAliases[strings.ToLower(m[1])]})
+		}
+
+		// "Unix" は個別の OS に展開する（"Unix and Windows" のような変更を Linux 向けの絞り込みで落とさないため）
+		if unixRegex.MatchString(s) {
+			for _, goos := range unixGOOS {
+				add(platform.Platform{GOOS: goos})
+			}
+		}
+	}
+
+	sort.Slice(platforms, func(i, j int) bool {
+		return platforms[i].String() < platforms[j].String()
+	})
+	return platforms
+}
+
+// tagPlatforms は変更に対象プラットフォームを設定する
+func tagPlatforms(changes []StandardLibraryChange) {
+	for i := range changes {
+		changes[i].Platforms = DetectPlatforms(changes[i].Package, changes[i].Subheading, changes[i].Description)
+	}
+}
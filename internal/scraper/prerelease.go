@@ -0,0 +1,81 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+
+	"go-ver-trace/internal/goversion"
+)
+
+// downloadsURL はダウンロードページの JSON（プレリリースを含む全バージョン）
+const downloadsURL = "https://go.dev/dl/?mode=json&include=all"
+
+// GetPrereleaseVersions はダウンロードページから、正式リリース前のマイナーバージョンの最新のプレリリース（"1.26rc1" など）を返す
+// 正式リリース済みのマイナーバージョンのプレリリースは含めない
+// 最新の正式リリースの次のマイナーバージョンにプレリリースがまだない場合は、tip の草稿を "1.27rc0" として含める
+func (rs *ReleaseScraper) GetPrereleaseVersions(ctx context.Context) ([]string, error) {
+	body, _, err := rs.fetch(ctx, downloadsURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch release catalog: %w", err)
+	}
+
+	var entries []struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(body, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse release catalog: %w", err)
+	}
+
+	var versions []string
+	for _, e := range entries {
+		versions = append(versions, e.Version)
+	}
+	return LatestPrereleases(versions), nil
+}
+
+// LatestPrereleases はバージョン一覧から、正式リリースがないマイナーバージョンごとに最新のプレリリースを返す
+// 最新の正式リリースの次のマイナーバージョン（開発中）は、プレリリースがなければ rc0 とする
+func LatestPrereleases(versions []string) []string {
+	released := make(map[string]bool)
+	latest := make(map[string]goversion.Version)
+	var newest goversion.Version
+	for _, s := range versions {
+		v, err := goversion.Parse(s)
+		if err != nil {
+			continue
+		}
+		if v.Pre == "" {
+			released[v.Branch()] = true
+			if v.Compare(newest) > 0 {
+				newest = v
+			}
+			continue
+		}
+		if cur, ok := latest[v.Branch()]; !ok || v.Compare(cur) > 0 {
+			latest[v.Branch()] = v
+		}
+	}
+
+	if newest.Major > 0 {
+		next := goversion.Version{Major: newest.Major, Minor: newest.Minor + 1, Pre: "rc"}
+		if _, ok := latest[next.Branch()]; !ok {
+			latest[next.Branch()] = next
+		}
+	}
+
+	var result []string
+	for branch, v := range latest {
+		if released[branch] {
+			continue
+		}
+		result = append(result, v.String())
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return goversion.Compare(result[i], result[j]) < 0
+	})
+	log.Printf("正式リリース前のプレリリース: %v", result)
+	return result
+}
@@ -1,14 +1,23 @@
 package scraper
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"log"
 	"net/http"
 	"regexp"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+
+	"go-ver-trace/internal/classifier"
+	"go-ver-trace/internal/goversion"
+	"go-ver-trace/internal/platform"
 )
 
 type ReleaseInfo struct {
@@ -16,62 +25,164 @@ type ReleaseInfo struct {
 	ReleaseDate time.Time
 	URL         string
 	Changes     []StandardLibraryChange
+	Godebugs    []GodebugChange
+	Diagnostics []Diagnostic
+	// NotModified は前回の取得からリリースノートが更新されておらず、解析を省略したことを示す
+	// （Changes などは空で、保存済みのデータをそのまま使う）
+	NotModified bool
+	// FetchFailed はリリースノートを取得・解析できなかったことを示す
+	// （Diagnostics に失敗の記録だけを持ち、リリースとしては保存しない）
+	FetchFailed bool
+}
+
+// Prerelease は正式リリース前（beta / rc）のリリースかどうかを返す
+func (r ReleaseInfo) Prerelease() bool {
+	return goversion.IsPrerelease(r.Version)
 }
 
 type StandardLibraryChange struct {
-	Package     string
-	ChangeType  string // "Added", "Modified", "Deprecated", "Removed"
-	Description string
-	SummaryJa   string // 日本語要約
+	Package              string
+	ChangeType           string  // 変更種別（classifier.Types のいずれか）
+	ChangeTypeConfidence float64 // 変更種別の判定の確信度（0〜1）
+	Description          string  // 説明文全体（プレーンテキスト）
+	DescriptionHTML      string  // 説明文全体（サニタイズ済み HTML）
+	Excerpt              string  // 一覧表示用の抜粋
+	SummaryJa            string  // 日本語要約
+	Links                []ChangeLink
+	Area                 string     // 領域（language, tools, runtime, compiler, linker, ports, stdlib）
+	Subheading           string     // セクション内の見出し（h3）
+	Experiment           string     // GOEXPERIMENT 名（実験的機能の場合）
+	ExperimentStatus     string     // experimental / default_on / removed
+	Platforms            []platform.Platform // 対象 GOOS/GOARCH（空の場合は全プラットフォーム共通）
 }
 
 type ReleaseScraper struct {
-	baseURL string
-	client  *http.Client
+	baseURL    string
+	client     *http.Client
+	overrides  map[string]ParserOverride // バージョンごとの解析方法の指定
+	classifier *classifier.Classifier    // 変更種別の判定規則
+	workers    int                       // 同時に取得するリリースノートの数
+	limiter    *rateLimiter              // go.dev へのリクエストの間隔の制限
+	maxRetries int
+	cache      *HTTPCache   // 条件付きリクエスト用の HTTP キャッシュ（nil の場合は使わない）
+	cacheHits  atomic.Int64 // 304 Not Modified で保存済みの本文を使った回数
+
+	// SkipUnchanged が true を返すバージョンは、リリースノートが更新されていなければ解析を省略する
+	// （保存済みのデータがあるバージョンを指定する）
+	SkipUnchanged func(version string) bool
 }
 
 func NewReleaseScraper() *ReleaseScraper {
+	overrides, err := parseParserOverrides(defaultParserOverrides)
+	if err != nil {
+		log.Printf("組み込みの解析方法の指定を読み込めませんでした: %v", err)
+	}
+
 	return &ReleaseScraper{
 		baseURL: "https://go.dev/doc/devel/release",
 		client: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		overrides:  overrides,
+		classifier: classifier.Default(),
+		workers:    DefaultWorkers,
+		limiter:    newRateLimiter(DefaultRequestsPerSec, DefaultWorkers),
+		maxRetries: defaultMaxRetries,
 	}
 }
 
-func (rs *ReleaseScraper) GetReleaseInfo(versions []string) ([]ReleaseInfo, error) {
-	var releases []ReleaseInfo
+// SetClassifier は変更種別の判定規則を設定する（既定は組み込みの規則）
+func (rs *ReleaseScraper) SetClassifier(c *classifier.Classifier) {
+	rs.classifier = c
+}
 
-	for _, version := range versions {
-		release, err := rs.scrapeReleaseInfo(version)
-		if err != nil {
-			log.Printf("Error scraping version %s: %v", version, err)
-			continue
+// GetReleaseInfo は複数のリリースノートを並行して取得し、versions の順に返す
+// ctx がキャンセルされた場合は取得中のリクエストを中断してエラーを返す
+func (rs *ReleaseScraper) GetReleaseInfo(ctx context.Context, versions []string) ([]ReleaseInfo, error) {
+	// リリース履歴ページは実行ごとに 1 回だけ取得し、全バージョンのリリース日を引く
+	history := rs.fetchReleaseHistory(ctx)
+
+	results := make([]*ReleaseInfo, len(versions))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < rs.workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				release, err := rs.scrapeReleaseInfo(ctx, versions[i], history)
+				if err != nil {
+					log.Printf("Error scraping version %s: %v", versions[i], err)
+					if release.FetchFailed {
+						results[i] = &release
+					}
+					continue
+				}
+				results[i] = &release
+			}
+		}()
+	}
+
+dispatch:
+	for i := range versions {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break dispatch
 		}
-		releases = append(releases, release)
+	}
+	close(jobs)
+	wg.Wait()
 
-		// レート制限のため少し待機
-		time.Sleep(1 * time.Second)
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("scraping canceled: %w", err)
 	}
 
+	var releases []ReleaseInfo
+	for _, release := range results {
+		if release != nil {
+			releases = append(releases, *release)
+		}
+	}
 	return releases, nil
 }
 
-func (rs *ReleaseScraper) scrapeReleaseInfo(version string) (ReleaseInfo, error) {
+// fetchFailed はリリースノートを取得・解析できなかった場合の結果を返す
+// 過去リリース（Go 1.18 より前）はダミーデータで補わず、失敗を診断にだけ記録してエラーを返す
+func (rs *ReleaseScraper) fetchFailed(pctx *parseContext, version, documentURL string, err error) (ReleaseInfo, error) {
+	pctx.report(DiagnosticFetchFailed, documentURL, err.Error())
+	if goversion.Compare(version, backfillBefore) < 0 {
+		return ReleaseInfo{Version: version, URL: documentURL, Diagnostics: pctx.diagnostics, FetchFailed: true}, err
+	}
+
+	log.Printf("Failed to fetch %s, using dummy data: %v", documentURL, err)
+	release := rs.generateDummyRelease(version)
+	release.Diagnostics = pctx.diagnostics
+	return release, nil
+}
+
+func (rs *ReleaseScraper) scrapeReleaseInfo(ctx context.Context, version string, history map[string]time.Time) (ReleaseInfo, error) {
 	// 公式ドキュメントURLを使用
 	documentURL := rs.GetVersionDocumentURL(version)
 
-	resp, err := rs.client.Get(documentURL)
+	pctx := &parseContext{rs: rs, version: version}
+
+	body, notModified, err := rs.fetch(ctx, documentURL)
+	if err == nil && notModified && rs.SkipUnchanged != nil && rs.SkipUnchanged(version) {
+		log.Printf("Go %s: リリースノートが更新されていないため解析を省略します", version)
+		return ReleaseInfo{Version: version, URL: documentURL, NotModified: true}, nil
+	}
 	if err != nil {
-		log.Printf("Failed to fetch %s, using dummy data: %v", documentURL, err)
-		return rs.generateDummyRelease(version), nil
+		// プレリリースはダミーデータで補わない（正式リリースと突き合わせるため）
+		if ctx.Err() != nil || goversion.IsPrerelease(version) {
+			return ReleaseInfo{}, err
+		}
+		return rs.fetchFailed(pctx, version, documentURL, err)
 	}
-	defer resp.Body.Close()
 
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
 	if err != nil {
-		log.Printf("Failed to parse HTML for %s, using dummy data: %v", documentURL, err)
-		return rs.generateDummyRelease(version), nil
+		return rs.fetchFailed(pctx, version, documentURL, err)
 	}
 
 	release := ReleaseInfo{
@@ -80,26 +191,65 @@ func (rs *ReleaseScraper) scrapeReleaseInfo(version string) (ReleaseInfo, error)
 	}
 
 	// リリース日を設定（実際のリリース日に基づく）
-	release.ReleaseDate = rs.getActualReleaseDate(version)
+	release.ReleaseDate = rs.getActualReleaseDate(version, history)
 
 	// 標準ライブラリの変更点を抽出
-	changes := rs.extractStandardLibraryChangesFromDocument(doc, version)
+	changes := rs.extractLibraryChanges(pctx, doc)
+	for i := range changes {
+		changes[i].Area = AreaStdlib
+	}
+	pctx.reportDuplicates(changes)
+
+	// 言語・ツール・ランタイムなど標準ライブラリ以外のセクションを抽出
+	changes = append(changes, rs.extractSectionChanges(doc, version)...)
 	release.Changes = changes
 
+	// 実験的パッケージ・GOEXPERIMENT の状態を判定
+	tagExperiments(release.Changes)
+	tagPlatforms(release.Changes)
+
+	// 変更の説明文から GODEBUG 設定への言及を抽出
+	release.Godebugs = ExtractGodebugChanges(release)
+
+	release.Diagnostics = pctx.diagnostics
+
 	log.Printf("Go %s: 抽出した変更数 %d", version, len(changes))
 
 	return release, nil
 }
 
-func (rs *ReleaseScraper) getActualReleaseDate(version string) time.Time {
-	// 公式リリース履歴ページから日付を取得
-	date := rs.fetchReleaseDateFromHistory(version)
-	if !date.IsZero() {
+func (rs *ReleaseScraper) getActualReleaseDate(version string, history map[string]time.Time) time.Time {
+	// 公式リリース履歴ページから取得した日付
+	if date, ok := history[version]; ok {
+		log.Printf("Go %s のリリース日を取得: %s", version, date.Format("2006-01-02"))
 		return date
 	}
 
+	// プレリリースはリリース履歴ページに載らないため、取得した日とする
+	if goversion.IsPrerelease(version) {
+		return time.Now().UTC().Truncate(24 * time.Hour)
+	}
+
 	// フォールバック用の日付
 	releaseDates := map[string]string{
+		"1.0":  "2012-03-28",
+		"1.1":  "2013-05-13",
+		"1.2":  "2013-12-01",
+		"1.3":  "2014-06-18",
+		"1.4":  "2014-12-10",
+		"1.5":  "2015-08-19",
+		"1.6":  "2016-02-17",
+		"1.7":  "2016-08-15",
+		"1.8":  "2017-02-16",
+		"1.9":  "2017-08-24",
+		"1.10": "2018-02-16",
+		"1.11": "2018-08-24",
+		"1.12": "2019-02-25",
+		"1.13": "2019-09-03",
+		"1.14": "2020-02-25",
+		"1.15": "2020-08-11",
+		"1.16": "2021-02-16",
+		"1.17": "2021-08-16",
 		"1.18": "2022-03-15",
 		"1.19": "2022-08-02",
 		"1.20": "2023-02-01",
@@ -120,63 +270,60 @@ func (rs *ReleaseScraper) getActualReleaseDate(version string) time.Time {
 	return time.Date(2023, 8, 8, 0, 0, 0, 0, time.UTC)
 }
 
-// 公式リリース履歴ページからリリース日を取得
-func (rs *ReleaseScraper) fetchReleaseDateFromHistory(version string) time.Time {
-	releaseHistoryURL := "https://go.dev/doc/devel/release"
+// "go1.21.0 (released 2023-08-08)" の形式（Go 1.20 以前は "go1.17 (released ...)"、Go 1.0 は "go1 (released ...)"）
+var releaseHistoryRegex = regexp.MustCompile(`\bgo(1(?:\.\d+)*)\s*\(released\s+(\d{4}-\d{2}-\d{2})\)`)
 
-	resp, err := rs.client.Get(releaseHistoryURL)
+// fetchReleaseHistory は公式リリース履歴ページからバージョンごとのリリース日を取得する
+// "1.21.0" は "1.21"、"1" は "1.0" として扱う。取得できない場合は空の map を返す
+func (rs *ReleaseScraper) fetchReleaseHistory(ctx context.Context) map[string]time.Time {
+	dates := make(map[string]time.Time)
+
+	body, _, err := rs.fetch(ctx, rs.baseURL)
 	if err != nil {
 		log.Printf("リリース履歴ページの取得に失敗: %v", err)
-		return time.Time{}
+		return dates
 	}
-	defer resp.Body.Close()
 
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
 	if err != nil {
 		log.Printf("リリース履歴ページの解析に失敗: %v", err)
-		return time.Time{}
+		return dates
 	}
 
-	// "go1.XX.0 (released YYYY-MM-DD)" の形式を探す
-	versionPattern := fmt.Sprintf("go%s.0", version)
-
-	var releaseDate time.Time
-	// より具体的な正規表現でバージョンと日付を同時に検索
-	versionDateRegex := regexp.MustCompile(fmt.Sprintf(`%s\s*\(released\s+(\d{4}-\d{2}-\d{2})\)`, regexp.QuoteMeta(versionPattern)))
-
-	doc.Find("*").EachWithBreak(func(i int, elem *goquery.Selection) bool {
-		text := elem.Text()
-		matches := versionDateRegex.FindStringSubmatch(text)
-		if len(matches) > 1 {
-			if date, err := time.Parse("2006-01-02", matches[1]); err == nil {
-				releaseDate = date
-				log.Printf("Go %s のリリース日を取得: %s", version, date.Format("2006-01-02"))
-				return false // 検索停止
-			}
+	for _, m := range releaseHistoryRegex.FindAllStringSubmatch(doc.Text(), -1) {
+		version := m[1]
+		switch {
+		case version == "1":
+			version = "1.0"
+		case strings.Count(version, ".") == 2 && strings.HasSuffix(version, ".0"):
+			version = strings.TrimSuffix(version, ".0")
 		}
-		return true // 検索続行
-	})
+		if _, exists := dates[version]; exists {
+			continue
+		}
+		if date, err := time.Parse("2006-01-02", m[2]); err == nil {
+			dates[version] = date
+		}
+	}
 
-	return releaseDate
+	log.Printf("リリース履歴ページから %d 件のリリース日を取得", len(dates))
+	return dates
 }
 
-func (rs *ReleaseScraper) extractStandardLibraryChangesFromDocument(doc *goquery.Document, version string) []StandardLibraryChange {
+func (rs *ReleaseScraper) extractStandardLibraryChangesFromDocument(ctx *parseContext, doc *goquery.Document) []StandardLibraryChange {
+	version := ctx.version
 	var changes []StandardLibraryChange
 
-	// h2でStandard Libraryセクションを探す
+	// h2でStandard Library（Go 1.7〜1.17 は Core library）セクションを探す
 	doc.Find("h2").Each(func(i int, h2Header *goquery.Selection) {
 		headerText := strings.ToLower(strings.TrimSpace(h2Header.Text()))
 
 		// "Standard Library"セクションを見つけた場合
-		if strings.Contains(headerText, "standard library") {
+		if isLibraryHeading(h2Header) {
 			log.Printf("Go %s: Standard Libraryセクションを発見: %q", version, headerText)
 
-			// Standard Library以降のh3タグを処理
-			h2Header.NextAll().Each(func(j int, elem *goquery.Selection) {
-				// 次のh2セクションに到達したら終了
-				if elem.Is("h2") {
-					return
-				}
+			// Standard Library以降、次のh2セクションまでのh3タグを処理
+			h2Header.NextUntil("h2").Each(func(j int, elem *goquery.Selection) {
 
 				// h3タグを処理
 				if elem.Is("h3") {
@@ -186,41 +333,40 @@ func (rs *ReleaseScraper) extractStandardLibraryChangesFromDocument(doc *goquery
 					// minor changes to the libraryセクションの場合
 					if strings.Contains(h3TextLower, "minor") && strings.Contains(h3TextLower, "library") {
 						log.Printf("Go %s: Minor changesセクションを処理: %q", version, h3Text)
-						rs.extractMinorChanges(elem, version, &changes)
+						start := len(changes)
+						rs.extractMinorChanges(ctx, elem, &changes)
+						for k := start; k < len(changes); k++ {
+							changes[k].Subheading = h3Text
+						}
 					} else {
 						// h3の内容をパッケージ名として処理
 						packageName := rs.extractPackageNameFromH3(h3Text)
 						description := rs.extractH3Description(elem)
 
 						if packageName != "" {
-							changeType := rs.determineChangeType(description)
-							summaryJa := rs.generateJapaneseSummary(description, changeType)
-							changes = append(changes, StandardLibraryChange{
-								Package:     packageName,
-								ChangeType:  changeType,
-								Description: description,
-								SummaryJa:   summaryJa,
-							})
+							change := rs.newChange(packageName, description)
+							change.Subheading = h3Text
+							changes = append(changes, change)
 
 							log.Printf("Go %s: パッケージ %s の変更を抽出", version, packageName)
 						}
 
 						// 説明文から追加のパッケージを抽出（例：encoding/json/jsontext）
-						additionalPackages := rs.extractPackagesFromDescription(description)
+						additionalPackages := rs.extractPackagesFromDescription(ctx, description.Text())
 						for _, addPkg := range additionalPackages {
 							if addPkg != packageName { // 重複回避
-								changeType := rs.determineChangeType(description)
-								summaryJa := rs.generateJapaneseSummary(description, changeType)
-								changes = append(changes, StandardLibraryChange{
-									Package:     addPkg,
-									ChangeType:  changeType,
-									Description: description,
-									SummaryJa:   summaryJa,
-								})
+								change := rs.newChange(addPkg, description)
+								change.Subheading = h3Text
+								changes = append(changes, change)
 
 								log.Printf("Go %s: 追加パッケージ %s の変更を抽出", version, addPkg)
 							}
 						}
+
+						// Go 1.7〜1.17 の話題ごとの h3 は extractCoreLibraryChanges で抽出する
+						if packageName == "" && len(additionalPackages) == 0 && ctx.layout == "standard" {
+							ctx.report(DiagnosticUnparsedHeading, h3Text, "h3")
+						}
 					}
 				}
 			})
@@ -277,7 +423,7 @@ func (rs *ReleaseScraper) extractPackageNameFromH3(h3Text string) string {
 }
 
 // 説明文からパッケージ名を抽出
-func (rs *ReleaseScraper) extractPackagesFromDescription(description string) []string {
+func (rs *ReleaseScraper) extractPackagesFromDescription(ctx *parseContext, description string) []string {
 	var packages []string
 
 	// より厳密なパッケージ名のパターンを抽出（スラッシュを含むものを優先）
@@ -287,19 +433,24 @@ func (rs *ReleaseScraper) extractPackagesFromDescription(description string) []s
 	for _, match := range matches {
 		if len(match) > 1 {
 			packageName := match[1]
-			if rs.isValidPackageName(packageName) && rs.isKnownStandardPackage(packageName) {
-				// 重複チェック
-				isDuplicate := false
-				for _, existing := range packages {
-					if existing == packageName {
-						isDuplicate = true
-						break
-					}
-				}
-				if !isDuplicate {
-					packages = append(packages, packageName)
+			if !rs.isValidPackageName(packageName) {
+				continue
+			}
+			if !rs.isKnownStandardPackage(packageName) {
+				ctx.report(DiagnosticUnknownPackage, packageName, "説明文中のパッケージ名を除外")
+				continue
+			}
+			// 重複チェック
+			isDuplicate := false
+			for _, existing := range packages {
+				if existing == packageName {
+					isDuplicate = true
+					break
 				}
 			}
+			if !isDuplicate {
+				packages = append(packages, packageName)
+			}
 		}
 	}
 
@@ -330,8 +481,8 @@ func (rs *ReleaseScraper) isKnownStandardPackage(packageName string) bool {
 }
 
 // h3の説明文を抽出
-func (rs *ReleaseScraper) extractH3Description(h3 *goquery.Selection) string {
-	var descriptions []string
+func (rs *ReleaseScraper) extractH3Description(h3 *goquery.Selection) description {
+	var desc description
 
 	// h3の次の要素から次のh3またはh2までの内容を収集
 	h3.NextAll().Each(func(i int, elem *goquery.Selection) {
@@ -341,135 +492,47 @@ func (rs *ReleaseScraper) extractH3Description(h3 *goquery.Selection) string {
 		}
 
 		if elem.Is("p") {
-			text := strings.TrimSpace(elem.Text())
-			if text != "" {
-				descriptions = append(descriptions, text)
-			}
+			desc.add(elem)
 		}
 	})
 
-	// 最初の200文字に制限
-	fullDescription := strings.Join(descriptions, " ")
-	if len(fullDescription) > 200 {
-		fullDescription = fullDescription[:200] + "..."
-	}
+	return desc
+}
 
-	return fullDescription
+// newChange は説明文から変更種別・日本語要約・抜粋を算出して変更を組み立てる
+func (rs *ReleaseScraper) newChange(packageName string, desc description) StandardLibraryChange {
+	text := desc.Text()
+	excerpt := MakeExcerpt(text)
+
+	// 種別判定と要約は従来どおり冒頭部分（抜粋）を対象にする
+	result := rs.classifier.Classify(excerpt)
+
+	return StandardLibraryChange{
+		Package:              packageName,
+		ChangeType:           result.Type,
+		ChangeTypeConfidence: result.Confidence,
+		Description:          text,
+		DescriptionHTML:      desc.HTML(),
+		Excerpt:              excerpt,
+		SummaryJa:            classifier.SummaryJa(excerpt, result.Type),
+		Links:                desc.Links(),
+	}
 }
 
 // Minor changesセクションを処理
-func (rs *ReleaseScraper) extractMinorChanges(h3 *goquery.Selection, version string, changes *[]StandardLibraryChange) {
-	// minor changes セクション以降のh4またはpタグを処理
-	h3.NextAll().Each(func(i int, elem *goquery.Selection) {
-		// 次のh3やh2に到達したら終了
-		if elem.Is("h2") || elem.Is("h3") {
-			return
-		}
-
-		// Go 1.22の場合は特別処理: dlタグ内のdtタグを処理
-		if version == "1.22" && elem.Is("dl") {
-			elem.Find("dt").Each(func(j int, dt *goquery.Selection) {
-				packageName := rs.extractPackageNameFromDt(dt)
-				if packageName != "" {
-					description := rs.extractDtDescription(dt)
-					changeType := rs.determineChangeType(description)
-					summaryJa := rs.generateJapaneseSummary(description, changeType)
-
-					*changes = append(*changes, StandardLibraryChange{
-						Package:     packageName,
-						ChangeType:  changeType,
-						Description: description,
-						SummaryJa:   summaryJa,
-					})
-
-					log.Printf("Go %s: パッケージ %s の変更を抽出 (dl->dt)", version, packageName)
-				}
-			})
-		} else if elem.Is("dl") {
-			// その他のバージョンでも dlタグ内のdtタグを処理
-			elem.Find("dt").Each(func(j int, dt *goquery.Selection) {
-				packageName := rs.extractPackageNameFromDt(dt)
-				if packageName != "" {
-					description := rs.extractDtDescription(dt)
-
-					// 説明文が空の場合は、次の要素から直接取得を試行
-					if description == "" {
-						description = rs.extractDescriptionFromDtSiblings(dt)
-					}
-
-					if description == "" {
-						log.Printf("警告: %s の %s パッケージの説明文が空です (dt)", version, packageName)
-						return
-					}
-
-					changeType := rs.determineChangeType(description)
-					summaryJa := rs.generateJapaneseSummary(description, changeType)
-
-					*changes = append(*changes, StandardLibraryChange{
-						Package:     packageName,
-						ChangeType:  changeType,
-						Description: description,
-						SummaryJa:   summaryJa,
-					})
-
-					log.Printf("Go %s: パッケージ %s の変更を抽出 (dl->dt)", version, packageName)
-				}
-			})
-		} else if version == "1.22" && elem.Is("p") {
-			text := elem.Text()
-			packageName := rs.extractPackageNameFromBrackets(text)
-
-			if packageName != "" {
-				description := strings.TrimSpace(text)
-				if len(description) > 200 {
-					description = description[:200] + "..."
-				}
-				changeType := rs.determineChangeType(description)
-				summaryJa := rs.generateJapaneseSummary(description, changeType)
-
-				*changes = append(*changes, StandardLibraryChange{
-					Package:     packageName,
-					ChangeType:  changeType,
-					Description: description,
-					SummaryJa:   summaryJa,
-				})
-
-				log.Printf("Go %s: パッケージ %s の変更を抽出 (p)", version, packageName)
-			}
-		} else if elem.Is("h4") {
-			// 他のバージョンの場合はh4タグを処理
-			h4Text := elem.Text()
-			log.Printf("Go %s: h4タグを発見: %q", version, h4Text)
-			
-			packageName := rs.extractPackageNameFromHeader(elem)
-			log.Printf("Go %s: h4タグから抽出したパッケージ名: %q", version, packageName)
-			
-			if packageName != "" {
-				description := rs.extractPackageDescription(elem)
-				log.Printf("Go %s: %s の説明文: %q", version, packageName, description)
-
-				// 説明文が空の場合は、次の要素から直接取得を試行
-				if description == "" {
-					description = rs.extractDescriptionFromNextElements(elem)
-					log.Printf("Go %s: %s の代替説明文: %q", version, packageName, description)
-				}
-
-				changeType := rs.determineChangeType(description)
-				summaryJa := rs.generateJapaneseSummary(description, changeType)
-
-				*changes = append(*changes, StandardLibraryChange{
-					Package:     packageName,
-					ChangeType:  changeType,
-					Description: description,
-					SummaryJa:   summaryJa,
-				})
+// 節のレイアウトに応じた解析方法（parserStrategy）を選び、それぞれの要素から変更を抽出する
+func (rs *ReleaseScraper) extractMinorChanges(ctx *parseContext, h3 *goquery.Selection, changes *[]StandardLibraryChange) {
+	section := h3.NextUntil("h2, h3")
+	strategies := rs.strategiesFor(ctx.version, section)
+	if len(strategies) == 0 {
+		ctx.report(DiagnosticUnparsedSection, normalizeSpace(h3.Text()), "レイアウトを判定できませんでした")
+		return
+	}
+	log.Printf("Go %s: Minor changesセクションの解析方法: %s", ctx.version, strategyNames(strategies))
 
-				log.Printf("Go %s: パッケージ %s の変更を抽出 (h4)", version, packageName)
-			} else {
-				log.Printf("Go %s: h4タグからパッケージ名を抽出できませんでした: %q", version, h4Text)
-			}
-		}
-	})
+	for _, strategy := range strategies {
+		*changes = append(*changes, strategy.Parse(ctx, section)...)
+	}
 }
 
 // dtタグからパッケージ名を抽出（Go 1.22用：hrefからパッケージ名を取得）
@@ -536,17 +599,47 @@ func (rs *ReleaseScraper) extractPackageNameFromHref(href string) string {
 }
 
 // dtタグの説明を抽出
-func (rs *ReleaseScraper) extractDtDescription(dt *goquery.Selection) string {
+func (rs *ReleaseScraper) extractDtDescription(dt *goquery.Selection) description {
+	var desc description
+
 	// dtの次のddタグの内容を取得
 	dd := dt.Next()
 	if dd.Is("dd") {
-		text := strings.TrimSpace(dd.Text())
-		if len(text) > 200 {
-			text = text[:200] + "..."
+		// dd 内の段落やリストはそれぞれ独立した説明として扱う
+		// （地の文やインライン要素を含む場合は dd 全体を 1 つの説明とする）
+		if hasOnlyBlockChildren(dd) {
+			dd.Children().Each(func(i int, child *goquery.Selection) {
+				desc.add(child)
+			})
+		} else {
+			desc.add(dd)
 		}
-		return text
+		return desc
 	}
-	return dt.Text()
+	desc.add(dt)
+	return desc
+}
+
+// hasOnlyBlockChildren は要素の中身が段落・リスト・整形済みテキストのみ（空白以外の地の文なし）かどうかを判定する
+func hasOnlyBlockChildren(s *goquery.Selection) bool {
+	if s.Children().Length() == 0 {
+		return false
+	}
+	for _, node := range s.Contents().Nodes {
+		switch node.Type {
+		case html.TextNode:
+			if strings.TrimSpace(node.Data) != "" {
+				return false
+			}
+		case html.ElementNode:
+			switch node.Data {
+			case "p", "ul", "ol", "pre":
+			default:
+				return false
+			}
+		}
+	}
+	return true
 }
 
 func (rs *ReleaseScraper) extractPackageNameFromHeader(h4 *goquery.Selection) string {
@@ -586,8 +679,8 @@ func (rs *ReleaseScraper) extractPackageNameFromHeader(h4 *goquery.Selection) st
 	return packageName
 }
 
-func (rs *ReleaseScraper) extractPackageDescription(h4 *goquery.Selection) string {
-	var descriptions []string
+func (rs *ReleaseScraper) extractPackageDescription(h4 *goquery.Selection) description {
+	var desc description
 	currentPackageName := rs.extractPackageNameFromHeader(h4)
 
 	// h4の次の要素から次のh4またはメジャーヘッダーまでの内容を収集
@@ -605,14 +698,14 @@ func (rs *ReleaseScraper) extractPackageDescription(h4 *goquery.Selection) strin
 				if isDifferent {
 					return false // ループを終了
 				}
-				descriptions = append(descriptions, text)
+				desc.add(elem)
 			}
 		} else if elem.Is("div") {
 			// divタグ内のpタグも探索
 			elem.Find("p").Each(func(j int, p *goquery.Selection) {
 				text := strings.TrimSpace(p.Text())
 				if text != "" && !rs.isDescriptionForDifferentPackage(text, currentPackageName) {
-					descriptions = append(descriptions, text)
+					desc.add(p)
 				}
 			})
 		} else if elem.Is("ul") || elem.Is("ol") {
@@ -620,7 +713,7 @@ func (rs *ReleaseScraper) extractPackageDescription(h4 *goquery.Selection) strin
 			elem.Find("li").Each(func(j int, li *goquery.Selection) {
 				text := strings.TrimSpace(li.Text())
 				if text != "" && !rs.isDescriptionForDifferentPackage(text, currentPackageName) {
-					descriptions = append(descriptions, text)
+					desc.add(li)
 				}
 			})
 		} else if elem.Is("dl") {
@@ -628,7 +721,7 @@ func (rs *ReleaseScraper) extractPackageDescription(h4 *goquery.Selection) strin
 			elem.Find("dd").Each(func(j int, dd *goquery.Selection) {
 				text := strings.TrimSpace(dd.Text())
 				if text != "" && !rs.isDescriptionForDifferentPackage(text, currentPackageName) {
-					descriptions = append(descriptions, text)
+					desc.add(dd)
 				}
 			})
 		}
@@ -636,32 +729,23 @@ func (rs *ReleaseScraper) extractPackageDescription(h4 *goquery.Selection) strin
 	})
 
 	// 説明文が取得できなかった場合は、より積極的に探索
-	if len(descriptions) == 0 {
+	if desc.empty() {
 		log.Printf("警告: %s の説明文が見つからない。より詳細な探索を実行", currentPackageName)
 		return rs.extractDescriptionFromNextElements(h4)
 	}
 
-	// 最初の200文字に制限
-	fullDescription := strings.Join(descriptions, " ")
-	if len(fullDescription) > 200 {
-		fullDescription = fullDescription[:200] + "..."
-	}
-
-	return fullDescription
+	return desc
 }
 
 // dtタグの兄弟要素から説明文を抽出する汎用メソッド
-func (rs *ReleaseScraper) extractDescriptionFromDtSiblings(dt *goquery.Selection) string {
-	var descriptions []string
+func (rs *ReleaseScraper) extractDescriptionFromDtSiblings(dt *goquery.Selection) description {
+	var desc description
 	currentPackageName := rs.extractPackageNameFromDt(dt)
 
 	// dtの次の要素（dd）を確認
 	nextElem := dt.Next()
 	if nextElem.Is("dd") {
-		text := strings.TrimSpace(nextElem.Text())
-		if text != "" {
-			descriptions = append(descriptions, text)
-		}
+		desc.add(nextElem)
 	}
 
 	// dtの後続要素も探索
@@ -674,30 +758,24 @@ func (rs *ReleaseScraper) extractDescriptionFromDtSiblings(dt *goquery.Selection
 		if elem.Is("dd") || elem.Is("p") {
 			text := strings.TrimSpace(elem.Text())
 			if text != "" && !rs.isDescriptionForDifferentPackage(text, currentPackageName) {
-				descriptions = append(descriptions, text)
+				desc.add(elem)
 			}
 		}
 
 		// 十分な情報が集まったら終了
-		if len(descriptions) > 0 && len(strings.Join(descriptions, " ")) > 50 {
+		if !desc.empty() && desc.length() > 50 {
 			return false
 		}
 
 		return true
 	})
 
-	// 結果をまとめて返す
-	fullDescription := strings.Join(descriptions, " ")
-	if len(fullDescription) > 200 {
-		fullDescription = fullDescription[:200] + "..."
-	}
-
-	return fullDescription
+	return desc
 }
 
 // 次の要素から説明文を直接抽出する汎用メソッド
-func (rs *ReleaseScraper) extractDescriptionFromNextElements(h4 *goquery.Selection) string {
-	var descriptions []string
+func (rs *ReleaseScraper) extractDescriptionFromNextElements(h4 *goquery.Selection) description {
+	var desc description
 	currentPackageName := rs.extractPackageNameFromHeader(h4)
 
 	// h4の直後の要素をより積極的に探索
@@ -715,9 +793,9 @@ func (rs *ReleaseScraper) extractDescriptionFromNextElements(h4 *goquery.Selecti
 			if text != "" {
 				// 他のパッケージの記述でないかチェック
 				if !rs.isDescriptionForDifferentPackage(text, currentPackageName) {
-					descriptions = append(descriptions, text)
+					desc.add(elem)
 					// 最初の意味のある記述を見つけたら、一定の長さで十分
-					if len(strings.Join(descriptions, " ")) > 100 {
+					if desc.length() > 100 {
 						return false
 					}
 				}
@@ -727,7 +805,7 @@ func (rs *ReleaseScraper) extractDescriptionFromNextElements(h4 *goquery.Selecti
 			elem.Find("dd").Each(func(j int, dd *goquery.Selection) {
 				text := strings.TrimSpace(dd.Text())
 				if text != "" && !rs.isDescriptionForDifferentPackage(text, currentPackageName) {
-					descriptions = append(descriptions, text)
+					desc.add(dd)
 				}
 			})
 		case "ul", "ol":
@@ -735,26 +813,20 @@ func (rs *ReleaseScraper) extractDescriptionFromNextElements(h4 *goquery.Selecti
 			elem.Find("li").Each(func(j int, li *goquery.Selection) {
 				text := strings.TrimSpace(li.Text())
 				if text != "" && !rs.isDescriptionForDifferentPackage(text, currentPackageName) {
-					descriptions = append(descriptions, text)
+					desc.add(li)
 				}
 			})
 		}
 
 		// 十分な情報が集まったら終了
-		if len(descriptions) > 0 && len(strings.Join(descriptions, " ")) > 50 {
+		if !desc.empty() && desc.length() > 50 {
 			return false
 		}
 
 		return true
 	})
 
-	// 結果をまとめて返す
-	fullDescription := strings.Join(descriptions, " ")
-	if len(fullDescription) > 200 {
-		fullDescription = fullDescription[:200] + "..."
-	}
-
-	return fullDescription
+	return desc
 }
 
 // 記述が異なるパッケージのものかどうかを判定
@@ -901,10 +973,11 @@ func (rs *ReleaseScraper) extractPackageNameFromText(text string) string {
 	return ""
 }
 
+// 段落の先頭の [package/name] 形式のパッケージ名
+var bracketRegex = regexp.MustCompile(`^\s*\[([a-z][a-z0-9]*(?:/[a-z][a-z0-9]*)*(?:/v[0-9]+)?)\]`)
+
 // 角括弧形式のパッケージ名を抽出（Go 1.22用）
 func (rs *ReleaseScraper) extractPackageNameFromBrackets(text string) string {
-	// [package/name] 形式のパッケージ名を抽出
-	bracketRegex := regexp.MustCompile(`\[([a-z][a-z0-9]*(?:/[a-z][a-z0-9]*)*(?:/v[0-9]+)?)\]`)
 	matches := bracketRegex.FindStringSubmatch(text)
 	if len(matches) > 1 && rs.isValidPackageName(matches[1]) {
 		return matches[1]
@@ -982,12 +1055,14 @@ func (rs *ReleaseScraper) generateDummyChanges(version string) []StandardLibrary
 			}
 
 			description := fmt.Sprintf("Go %s での %s パッケージの改善", version, pkg)
-			summaryJa := rs.generateJapaneseSummary(description, changeType)
+			summaryJa := classifier.SummaryJa(description, changeType)
 			changes = append(changes, StandardLibraryChange{
 				Package:     pkg,
 				ChangeType:  changeType,
 				Description: description,
+				Excerpt:     description,
 				SummaryJa:   summaryJa,
+				Area:        AreaStdlib,
 			})
 		}
 	}
@@ -995,103 +1070,31 @@ func (rs *ReleaseScraper) generateDummyChanges(version string) []StandardLibrary
 	return changes
 }
 
-func (rs *ReleaseScraper) determineChangeType(description string) string {
-	description = strings.ToLower(description)
-
-	if strings.Contains(description, "new") || strings.Contains(description, "added") {
-		return "Added"
-	}
-	if strings.Contains(description, "deprecated") {
-		return "Deprecated"
-	}
-	if strings.Contains(description, "removed") || strings.Contains(description, "deleted") {
-		return "Removed"
-	}
-	return "Modified"
+func (rs *ReleaseScraper) GetTargetVersions() []string {
+	// Go 1.18-1.25の8世代
+	return []string{"1.18", "1.19", "1.20", "1.21", "1.22", "1.23", "1.24", "1.25"}
 }
 
-// 英語の変更内容を日本語で要約
-func (rs *ReleaseScraper) generateJapaneseSummary(description, changeType string) string {
-	if description == "" {
-		return ""
-	}
-
-	// 基本的なパターンマッチングによる要約
-	description = strings.ToLower(description)
-
-	switch changeType {
-	case "Added":
-		if strings.Contains(description, "method") {
-			if strings.Contains(description, "new method") || strings.Contains(description, "added method") {
-				return "新しいメソッドが追加されました"
-			}
-		}
-		if strings.Contains(description, "function") {
-			return "新しい関数が追加されました"
-		}
-		if strings.Contains(description, "type") {
-			return "新しい型が追加されました"
-		}
-		if strings.Contains(description, "field") {
-			return "新しいフィールドが追加されました"
-		}
-		if strings.Contains(description, "constant") || strings.Contains(description, "const") {
-			return "新しい定数が追加されました"
-		}
-		if strings.Contains(description, "variable") || strings.Contains(description, "var") {
-			return "新しい変数が追加されました"
-		}
-		return "新機能が追加されました"
-
-	case "Modified":
-		if strings.Contains(description, "performance") {
-			return "パフォーマンスが改善されました"
-		}
-		if strings.Contains(description, "behavior") || strings.Contains(description, "behaviour") {
-			return "動作が変更されました"
-		}
-		if strings.Contains(description, "error") {
-			return "エラー処理が改善されました"
-		}
-		if strings.Contains(description, "documentation") || strings.Contains(description, "doc") {
-			return "ドキュメントが更新されました"
-		}
-		if strings.Contains(description, "fix") {
-			return "バグが修正されました"
-		}
-		if strings.Contains(description, "support") {
-			return "サポートが拡張されました"
-		}
-		return "機能が改善されました"
-
-	case "Deprecated":
-		if strings.Contains(description, "method") {
-			return "メソッドが非推奨になりました"
-		}
-		if strings.Contains(description, "function") {
-			return "関数が非推奨になりました"
-		}
-		return "非推奨となりました"
-
-	case "Removed":
-		if strings.Contains(description, "method") {
-			return "メソッドが削除されました"
-		}
-		if strings.Contains(description, "function") {
-			return "関数が削除されました"
-		}
-		return "機能が削除されました"
+// backfillBefore より前のリリースは -backfill でのみ取得する過去リリース
+const backfillBefore = "1.18"
 
-	default:
-		return "変更が行われました"
+// GetBackfillVersions は Go 1.18 より前の過去リリース（Go 1.0〜1.17）を返す
+func (rs *ReleaseScraper) GetBackfillVersions() []string {
+	versions := []string{"1.0"}
+	for minor := 1; minor <= 17; minor++ {
+		versions = append(versions, fmt.Sprintf("1.%d", minor))
 	}
-}
-
-func (rs *ReleaseScraper) GetTargetVersions() []string {
-	// Go 1.18-1.25の8世代
-	return []string{"1.18", "1.19", "1.20", "1.21", "1.22", "1.23", "1.24", "1.25"}
+	return versions
 }
 
 func (rs *ReleaseScraper) GetVersionDocumentURL(version string) string {
+	// Go 1.0 のリリースノートは "Go 1 Release Notes"（/doc/go1）
+	if version == "1.0" {
+		return "https://go.dev/doc/go1"
+	}
+	// プレリリースは tip の草稿（正式リリースまで更新が続く）
+	if v, err := goversion.Parse(version); err == nil && v.Pre != "" {
+		return fmt.Sprintf("https://tip.golang.org/doc/go%s", v.Branch())
+	}
 	return fmt.Sprintf("https://go.dev/doc/go%s#library", version)
 }
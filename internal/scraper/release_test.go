@@ -0,0 +1,65 @@
+package scraper
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// roundTripFunc は関数を http.RoundTripper として使う（go.dev に接続せずに応答を返すため）
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// newStubScraper は transport の応答を使うスクレイパーを作る（テストを待たせないようリクエストの間隔を短くする）
+func newStubScraper(transport roundTripFunc) *ReleaseScraper {
+	rs := NewReleaseScraper()
+	rs.client = &http.Client{Transport: transport}
+	rs.limiter = newRateLimiter(1000, 10)
+	return rs
+}
+
+func stubResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+// TestGetReleaseInfoFetchFailed は取得できなかった過去リリースをダミーデータで補わず、
+// 失敗を診断にだけ記録することを確認する
+func TestGetReleaseInfoFetchFailed(t *testing.T) {
+	rs := newStubScraper(func(req *http.Request) (*http.Response, error) {
+		return stubResponse(http.StatusNotFound, ""), nil
+	})
+
+	releases, err := rs.GetReleaseInfo(context.Background(), []string{"1.5", "1.22"})
+	if err != nil {
+		t.Fatalf("GetReleaseInfo: %v", err)
+	}
+	if len(releases) != 2 {
+		t.Fatalf("got %d releases, want 2", len(releases))
+	}
+
+	backfill := releases[0]
+	if backfill.Version != "1.5" || !backfill.FetchFailed {
+		t.Errorf("Go 1.5: FetchFailed = %v, want true", backfill.FetchFailed)
+	}
+	if len(backfill.Changes) != 0 {
+		t.Errorf("Go 1.5: got %d dummy changes, want none", len(backfill.Changes))
+	}
+	if len(backfill.Diagnostics) != 1 || backfill.Diagnostics[0].Kind != DiagnosticFetchFailed {
+		t.Errorf("Go 1.5: diagnostics = %+v, want one %s", backfill.Diagnostics, DiagnosticFetchFailed)
+	}
+
+	// Go 1.18 以降は従来どおりダミーデータで補う
+	current := releases[1]
+	if current.FetchFailed || len(current.Changes) == 0 {
+		t.Errorf("Go 1.22: FetchFailed = %v, changes = %d, want dummy data", current.FetchFailed, len(current.Changes))
+	}
+}
@@ -0,0 +1,139 @@
+package scraper
+
+import (
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// 変更の領域（リリースノートの h2 セクションに対応）
+const (
+	AreaLanguage = "language"
+	AreaTools    = "tools"
+	AreaRuntime  = "runtime"
+	AreaCompiler = "compiler"
+	AreaLinker   = "linker"
+	AreaPorts    = "ports"
+	AreaStdlib   = "stdlib"
+)
+
+// Areas は扱う領域の一覧
+var Areas = []string{AreaLanguage, AreaTools, AreaRuntime, AreaCompiler, AreaLinker, AreaPorts, AreaStdlib}
+
+// ツールの h3 見出しとコマンドの対応
+var toolCommands = map[string]string{
+	"go command":      "cmd/go",
+	"vet":             "cmd/vet",
+	"cgo":             "cmd/cgo",
+	"trace":           "cmd/trace",
+	"gofmt":           "cmd/gofmt",
+	"cover":           "cmd/cover",
+	"doc":             "cmd/doc",
+	"godoc":           "cmd/doc",
+	"pprof":           "cmd/pprof",
+	"objdump":         "cmd/objdump",
+	"go distribution": "cmd/dist",
+	"bootstrap":       "cmd/dist",
+}
+
+// portsHeadingRegex は "Ports" 節の見出し（"support" や "import" などの語には一致しない）
+var portsHeadingRegex = regexp.MustCompile(`\bports?\b`)
+
+// areaForHeading は h2 見出しから領域を判定する（対象外の場合は空文字列）
+func areaForHeading(heading string) string {
+	heading = strings.ToLower(strings.TrimSpace(heading))
+
+	switch {
+	case strings.Contains(heading, "standard library") || strings.Contains(heading, "core library") ||
+		strings.Contains(heading, "changes to the librar"):
+		return AreaStdlib
+	case strings.Contains(heading, "language"):
+		return AreaLanguage
+	case strings.Contains(heading, "tool") || strings.Contains(heading, "bootstrap") || strings.Contains(heading, "go command"):
+		return AreaTools
+	case strings.Contains(heading, "runtime"):
+		return AreaRuntime
+	case strings.Contains(heading, "compiler"):
+		return AreaCompiler
+	case strings.Contains(heading, "linker"):
+		return AreaLinker
+	case portsHeadingRegex.MatchString(heading):
+		return AreaPorts
+	}
+
+	return ""
+}
+
+// areaPackage は標準ライブラリ以外の領域での変更の対象名を決める
+func areaPackage(area, subheading string) string {
+	key := strings.ToLower(strings.TrimSpace(subheading))
+
+	switch area {
+	case AreaTools:
+		if cmd, ok := toolCommands[key]; ok {
+			return cmd
+		}
+		return "cmd"
+	case AreaCompiler:
+		return "cmd/compile"
+	case AreaLinker:
+		return "cmd/link"
+	case AreaRuntime:
+		return "runtime"
+	case AreaPorts:
+		if key == "" {
+			return "ports"
+		}
+		return "ports/" + strings.Join(strings.Fields(key), "-")
+	default:
+		return area
+	}
+}
+
+// extractSectionChanges は標準ライブラリ以外のセクション（言語・ツール・ランタイムなど）の変更を抽出する
+// h3 見出しごとに 1 件、h3 の前にある本文はセクション全体の変更としてまとめる
+func (rs *ReleaseScraper) extractSectionChanges(doc *goquery.Document, version string) []StandardLibraryChange {
+	var changes []StandardLibraryChange
+
+	doc.Find("h2").Each(func(i int, h2Header *goquery.Selection) {
+		area := areaForHeading(h2Header.Text())
+		if area == "" || area == AreaStdlib {
+			return
+		}
+
+		subheading := ""
+		var desc description
+
+		flush := func() {
+			if desc.empty() {
+				return
+			}
+			change := rs.newChange(areaPackage(area, subheading), desc)
+			change.Area = area
+			change.Subheading = subheading
+			changes = append(changes, change)
+			log.Printf("Go %s: %s セクションの変更を抽出 (%q)", version, area, subheading)
+		}
+
+		h2Header.NextAll().EachWithBreak(func(j int, elem *goquery.Selection) bool {
+			if elem.Is("h2") {
+				return false
+			}
+			if elem.Is("h3") {
+				flush()
+				subheading = strings.TrimSpace(elem.Text())
+				desc = description{}
+				return true
+			}
+			if elem.Is("p, ul, ol, pre, dl, blockquote, h4") {
+				desc.add(elem)
+			}
+			return true
+		})
+		flush()
+	})
+
+	return changes
+}
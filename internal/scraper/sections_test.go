@@ -0,0 +1,21 @@
+package scraper
+
+import "testing"
+
+func TestAreaForHeading(t *testing.T) {
+	for heading, want := range map[string]string{
+		"Ports":                   AreaPorts,
+		"Port to Windows on ARM":  AreaPorts,
+		"Core library":            AreaStdlib,
+		"Changes to the language": AreaLanguage,
+		"Runtime":                 AreaRuntime,
+		// "port" を含むだけの語は Ports 節ではない
+		"Support for older systems": "",
+		"Bug reports":               "",
+		"Imports":                   "",
+	} {
+		if got := areaForHeading(heading); got != want {
+			t.Errorf("areaForHeading(%q) = %q, want %q", heading, got, want)
+		}
+	}
+}
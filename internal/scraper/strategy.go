@@ -0,0 +1,293 @@
+package scraper
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// parseContext は解析中のリリースの情報
+type parseContext struct {
+	rs          *ReleaseScraper
+	version     string
+	layout      string       // ライブラリ節のレイアウト名
+	diagnostics []Diagnostic // 解析中に見つかった問題
+}
+
+// parserStrategy は "Minor changes to the library" 節のレイアウトごとの解析方法
+type parserStrategy interface {
+	// Name は設定ファイルで指定する名前
+	Name() string
+	// Detect は節の要素にこのレイアウトが含まれるか判定する
+	Detect(section *goquery.Selection) bool
+	// Parse は節の要素から変更を抽出する
+	Parse(ctx *parseContext, section *goquery.Selection) []StandardLibraryChange
+}
+
+// parserStrategies は登録済みの解析方法（検出時はこの順に適用する）
+var parserStrategies = []parserStrategy{
+	dlStrategy{},
+	markdownStrategy{},
+	h4Strategy{},
+	bracketStrategy{},
+}
+
+// strategyByName は名前から解析方法を返す
+func strategyByName(name string) (parserStrategy, bool) {
+	for _, s := range parserStrategies {
+		if s.Name() == name {
+			return s, true
+		}
+	}
+	return nil, false
+}
+
+// ParserOverride はバージョンごとの解析方法の指定
+type ParserOverride struct {
+	Layout     string   `json:"layout,omitempty"`     // ライブラリ節のレイアウト（topics / core / standard）
+	Strategies []string `json:"strategies,omitempty"` // Minor changes 節に適用する解析方法（自動検出の代わり）
+}
+
+//go:embed parser_overrides.json
+var defaultParserOverrides []byte
+
+// parseParserOverrides はバージョンをキーとした JSON を読み込み、名前を検証する
+func parseParserOverrides(data []byte) (map[string]ParserOverride, error) {
+	var overrides map[string]ParserOverride
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("failed to parse parser overrides: %w", err)
+	}
+
+	for version, o := range overrides {
+		if o.Layout != "" {
+			if _, ok := layoutByName(o.Layout); !ok {
+				return nil, fmt.Errorf("unknown layout %q for Go %s", o.Layout, version)
+			}
+		}
+		for _, name := range o.Strategies {
+			if _, ok := strategyByName(name); !ok {
+				return nil, fmt.Errorf("unknown parser strategy %q for Go %s", name, version)
+			}
+		}
+	}
+	return overrides, nil
+}
+
+// LoadParserOverrides はファイルからバージョンごとの解析方法の指定を読み込む（組み込みの指定を置き換える）
+func (rs *ReleaseScraper) LoadParserOverrides(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read parser overrides: %w", err)
+	}
+	overrides, err := parseParserOverrides(data)
+	if err != nil {
+		return err
+	}
+	rs.overrides = overrides
+	return nil
+}
+
+// strategiesFor は節に適用する解析方法を返す
+// バージョンの指定があればそれを使い、なければ節の要素から自動検出する
+func (rs *ReleaseScraper) strategiesFor(version string, section *goquery.Selection) []parserStrategy {
+	if o, ok := rs.overrides[version]; ok && len(o.Strategies) > 0 {
+		var strategies []parserStrategy
+		for _, name := range o.Strategies {
+			s, _ := strategyByName(name)
+			strategies = append(strategies, s)
+		}
+		return strategies
+	}
+
+	var detected []parserStrategy
+	for _, s := range parserStrategies {
+		if s.Detect(section) {
+			detected = append(detected, s)
+		}
+	}
+	return detected
+}
+
+func normalizeSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func strategyNames(strategies []parserStrategy) string {
+	var names []string
+	for _, s := range strategies {
+		names = append(names, s.Name())
+	}
+	return strings.Join(names, ", ")
+}
+
+// dlStrategy は <dl><dt>パッケージ</dt><dd>説明</dd></dl> 形式（Go 1.7〜1.22）
+type dlStrategy struct{}
+
+func (dlStrategy) Name() string { return "dl" }
+
+func (dlStrategy) Detect(section *goquery.Selection) bool {
+	return section.Filter("dl").Find("dt").Length() > 0
+}
+
+func (dlStrategy) Parse(ctx *parseContext, section *goquery.Selection) []StandardLibraryChange {
+	rs := ctx.rs
+	var changes []StandardLibraryChange
+
+	section.Filter("dl").Find("dt").Each(func(j int, dt *goquery.Selection) {
+		packageName := rs.extractPackageNameFromDt(dt)
+		if packageName == "" {
+			return
+		}
+
+		description := rs.extractDtDescription(dt)
+
+		// 説明文が空の場合は、次の要素から直接取得を試行
+		if description.empty() {
+			description = rs.extractDescriptionFromDtSiblings(dt)
+		}
+
+		if description.empty() {
+			ctx.report(DiagnosticEmptyDescription, packageName, "dt")
+			return
+		}
+
+		changes = append(changes, rs.newChange(packageName, description))
+		log.Printf("Go %s: パッケージ %s の変更を抽出 (dl->dt)", ctx.version, packageName)
+	})
+
+	return changes
+}
+
+// markdownStrategy は Markdown から生成された <h4><a href="/pkg/...">パッケージ</a></h4> 形式
+type markdownStrategy struct{}
+
+func (markdownStrategy) Name() string { return "markdown" }
+
+func (markdownStrategy) Detect(section *goquery.Selection) bool {
+	return markdownHeadings(section).Length() > 0
+}
+
+func (markdownStrategy) Parse(ctx *parseContext, section *goquery.Selection) []StandardLibraryChange {
+	rs := ctx.rs
+	var changes []StandardLibraryChange
+
+	markdownHeadings(section).Each(func(i int, h4 *goquery.Selection) {
+		href, _ := h4.Find(`a[href*="/pkg/"]`).First().Attr("href")
+		packageName := rs.legacyPackageFromHref(href)
+		if packageName == "" {
+			ctx.report(DiagnosticUnparsedHeading, normalizeSpace(h4.Text()), fmt.Sprintf("h4 のリンク %q", href))
+			return
+		}
+
+		description := rs.extractPackageDescription(h4)
+		if description.empty() {
+			ctx.report(DiagnosticEmptyDescription, packageName, "h4")
+			return
+		}
+
+		changes = append(changes, rs.newChange(packageName, description))
+		log.Printf("Go %s: パッケージ %s の変更を抽出 (markdown)", ctx.version, packageName)
+	})
+
+	return changes
+}
+
+// markdownHeadings はパッケージドキュメントへのリンクを持つ h4 を返す
+func markdownHeadings(section *goquery.Selection) *goquery.Selection {
+	return section.Filter("h4").FilterFunction(func(i int, h4 *goquery.Selection) bool {
+		return h4.Find(`a[href*="/pkg/"]`).Length() > 0
+	})
+}
+
+// h4Strategy は <h4>パッケージ</h4> の後に段落が続く形式（リンクのない h4）
+type h4Strategy struct{}
+
+func (h4Strategy) Name() string { return "h4" }
+
+func (h4Strategy) Detect(section *goquery.Selection) bool {
+	return plainHeadings(section).Length() > 0
+}
+
+func (h4Strategy) Parse(ctx *parseContext, section *goquery.Selection) []StandardLibraryChange {
+	rs := ctx.rs
+	version := ctx.version
+	var changes []StandardLibraryChange
+
+	plainHeadings(section).Each(func(i int, elem *goquery.Selection) {
+		h4Text := elem.Text()
+		log.Printf("Go %s: h4タグを発見: %q", version, h4Text)
+
+		packageName := rs.extractPackageNameFromHeader(elem)
+		log.Printf("Go %s: h4タグから抽出したパッケージ名: %q", version, packageName)
+
+		if packageName == "" {
+			ctx.report(DiagnosticUnparsedHeading, normalizeSpace(h4Text), "h4")
+			return
+		}
+
+		description := rs.extractPackageDescription(elem)
+		log.Printf("Go %s: %s の説明文: %q", version, packageName, MakeExcerpt(description.Text()))
+
+		// 説明文が空の場合は、次の要素から直接取得を試行
+		if description.empty() {
+			description = rs.extractDescriptionFromNextElements(elem)
+			log.Printf("Go %s: %s の代替説明文: %q", version, packageName, MakeExcerpt(description.Text()))
+		}
+		if description.empty() {
+			ctx.report(DiagnosticEmptyDescription, packageName, "h4")
+			return
+		}
+
+		changes = append(changes, rs.newChange(packageName, description))
+		log.Printf("Go %s: パッケージ %s の変更を抽出 (h4)", version, packageName)
+	})
+
+	return changes
+}
+
+// plainHeadings はパッケージドキュメントへのリンクを持たない h4 を返す
+func plainHeadings(section *goquery.Selection) *goquery.Selection {
+	return section.Filter("h4").FilterFunction(func(i int, h4 *goquery.Selection) bool {
+		return h4.Find(`a[href*="/pkg/"]`).Length() == 0
+	})
+}
+
+// bracketStrategy は "[net/http] ..." のように段落の先頭にパッケージ名を角括弧で書く形式（Go 1.22）
+type bracketStrategy struct{}
+
+func (bracketStrategy) Name() string { return "brackets" }
+
+func (bracketStrategy) Detect(section *goquery.Selection) bool {
+	return bracketParagraphs(section).Length() > 0
+}
+
+func (bracketStrategy) Parse(ctx *parseContext, section *goquery.Selection) []StandardLibraryChange {
+	rs := ctx.rs
+	var changes []StandardLibraryChange
+
+	bracketParagraphs(section).Each(func(i int, p *goquery.Selection) {
+		packageName := rs.extractPackageNameFromBrackets(p.Text())
+		if packageName == "" {
+			return
+		}
+
+		var description description
+		description.add(p)
+		changes = append(changes, rs.newChange(packageName, description))
+		log.Printf("Go %s: パッケージ %s の変更を抽出 (p)", ctx.version, packageName)
+	})
+
+	return changes
+}
+
+// bracketParagraphs は先頭が "[パッケージ]" の段落を返す
+func bracketParagraphs(section *goquery.Selection) *goquery.Selection {
+	return section.Filter("p").FilterFunction(func(i int, p *goquery.Selection) bool {
+		return bracketRegex.MatchString(p.Text())
+	})
+}
@@ -0,0 +1,38 @@
+package scraper
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// TestH4StrategySkipsEmptyDescription は説明文のない h4 を変更にせず、診断に記録することを確認する
+func TestH4StrategySkipsEmptyDescription(t *testing.T) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(`
+<h4 id="bufio">bufio</h4>
+<h4 id="net/http">net/http</h4>
+<p>The new http.Cookie.Quoted field indicates whether the value was originally quoted.</p>
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rs := NewReleaseScraper()
+	ctx := &parseContext{rs: rs, version: "1.23"}
+	changes := h4Strategy{}.Parse(ctx, doc.Find("body").Children())
+
+	if len(changes) != 1 || changes[0].Package != "net/http" {
+		var got []string
+		for _, c := range changes {
+			got = append(got, c.Package)
+		}
+		t.Fatalf("extracted %v, want [net/http]", got)
+	}
+	if len(ctx.diagnostics) != 1 {
+		t.Fatalf("diagnostics = %+v, want one %s", ctx.diagnostics, DiagnosticEmptyDescription)
+	}
+	if d := ctx.diagnostics[0]; d.Kind != DiagnosticEmptyDescription || d.Subject != "bufio" {
+		t.Errorf("diagnostic = %+v, want %s for bufio", d, DiagnosticEmptyDescription)
+	}
+}
@@ -1,20 +1,32 @@
 package server
 
 import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"html/template"
 	"log"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"go-ver-trace/internal/database"
+	"go-ver-trace/internal/importer"
+	"go-ver-trace/internal/langtag"
+	"go-ver-trace/internal/platform"
+	"go-ver-trace/internal/scraper"
 )
 
 type Server struct {
-	db       *database.Database
-	templates *template.Template
-	port     int
+	db         *database.Database
+	templates  *template.Template
+	port       int
+	adminToken string // 管理 API の認証トークン（空の場合は管理 API を無効にする）
 }
 
 type PageData struct {
@@ -33,12 +45,18 @@ func New(db *database.Database, port int) *Server {
 	return s
 }
 
+// SetAdminToken は管理 API（/api/admin/）の認証トークンを設定する
+func (s *Server) SetAdminToken(token string) {
+	s.adminToken = token
+}
+
 func (s *Server) loadTemplates() {
 	// テンプレートが存在しない場合は後で作成する
 	s.templates = template.New("")
 }
 
-func (s *Server) Start() error {
+// Start はサーバーを起動し、ctx がキャンセルされると処理中のリクエストを待って停止する
+func (s *Server) Start(ctx context.Context) error {
 	mux := http.NewServeMux()
 	
 	// APIルート（CORSで保護）
@@ -46,7 +64,20 @@ func (s *Server) Start() error {
 	mux.HandleFunc("/api/packages", s.apiPackagesHandler)
 	mux.HandleFunc("/api/package/", s.apiPackageHandler)
 	mux.HandleFunc("/api/visualization", s.apiVisualizationHandler)
+	mux.HandleFunc("/api/areas", s.apiAreasHandler)
+	mux.HandleFunc("/api/schemas/minor-revisions", s.apiMinorRevisionSchemaHandler)
+	mux.HandleFunc("/api/diff", s.apiDiffHandler)
+	mux.HandleFunc("/api/godebug", s.apiGodebugHandler)
+	mux.HandleFunc("/api/godebug/flips", s.apiGodebugFlipsHandler)
+	mux.HandleFunc("/api/experiments", s.apiExperimentsHandler)
+	mux.HandleFunc("/api/experiments/", s.apiExperimentHandler)
+	mux.HandleFunc("/api/ingestions", s.apiIngestionsHandler)
+	mux.HandleFunc("/api/ingestions/", s.apiIngestionHandler)
 	mux.HandleFunc("/api/refresh", s.apiRefreshHandler)
+	mux.HandleFunc("/api/admin/overrides", s.apiOverridesHandler)
+	mux.HandleFunc("/api/admin/overrides/", s.apiOverrideHandler)
+	mux.HandleFunc("/api/admin/translations", s.apiTranslationsHandler)
+	mux.HandleFunc("/api/admin/translations/", s.apiTranslationReviewHandler)
 	mux.HandleFunc("/api/health", s.healthHandler)
 	
 	// 静的ファイル（開発時のフォールバック）
@@ -56,7 +87,24 @@ func (s *Server) Start() error {
 	log.Printf("APIサーバーをポート %d で開始します", s.port)
 	log.Printf("API Endpoint: http://localhost%s/api/", addr)
 	
-	return http.ListenAndServe(addr, s.corsMiddleware(mux))
+	srv := &http.Server{Addr: addr, Handler: s.corsMiddleware(mux)}
+	shutdownErr := make(chan error, 1)
+	go func() {
+		<-ctx.Done()
+		log.Printf("APIサーバーを停止します")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		shutdownErr <- srv.Shutdown(shutdownCtx)
+	}()
+
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	// ListenAndServe は Shutdown の開始直後に戻るため、処理中のリクエストが終わるまで待つ
+	if err := <-shutdownErr; err != nil {
+		return fmt.Errorf("failed to shut down API server: %w", err)
+	}
+	return nil
 }
 
 func (s *Server) corsMiddleware(next http.Handler) http.Handler {
@@ -64,7 +112,7 @@ func (s *Server) corsMiddleware(next http.Handler) http.Handler {
 		// CORS headers
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With, X-Actor")
 		w.Header().Set("Access-Control-Max-Age", "86400")
 		
 		// JSON response header
@@ -370,7 +418,12 @@ func (s *Server) apiReleasesHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) apiPackagesHandler(w http.ResponseWriter, r *http.Request) {
-	packages, err := s.db.GetUniquePackages()
+	areas, ok := areaFilter(w, r)
+	if !ok {
+		return
+	}
+
+	packages, err := s.db.GetPackagesInAreas(areas)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -387,18 +440,112 @@ func (s *Server) apiPackageHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	
-	changes, err := s.db.GetPackageEvolution(packageName)
+	format, ok := descriptionFormat(w, r)
+	if !ok {
+		return
+	}
+
+	areas, ok := areaFilter(w, r)
+	if !ok {
+		return
+	}
+
+	platforms, ok := platformFilter(w, r)
+	if !ok {
+		return
+	}
+
+	upcoming, ok := upcomingFilter(w, r)
+	if !ok {
+		return
+	}
+
+	langs, ok := summaryLanguages(w, r)
+	if !ok {
+		return
+	}
+
+	changes, err := s.db.GetPackageEvolution(packageName, upcoming)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	changeIDs := make([]int, len(changes))
+	for i, change := range changes {
+		changeIDs[i] = change.ID
+	}
+
+	links, err := s.db.GetChangeLinksOf(changeIDs)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	changePlatforms, err := s.db.GetChangePlatformsOf(changeIDs)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+
+	translations, err := s.db.GetChangeTranslationsOf(changeIDs)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// 要求された表現形式の説明文・言語の要約を設定し、領域・プラットフォームで絞り込む
+	opts := database.VisualizationOptions{Areas: areas, Platforms: platforms}
+	filtered := []database.PackageChange{}
+	for _, change := range changes {
+		if !opts.IncludesArea(change.Area) || !opts.IncludesPlatforms(changePlatforms[change.ID]) {
+			continue
+		}
+		change = change.Localized(translations[change.ID], langs)
+		change.Description = change.DescriptionAs(format)
+		change.Links = links[change.ID]
+		change.Platforms = changePlatforms[change.ID]
+		filtered = append(filtered, change)
+	}
+	changes = filtered
 	
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(changes)
 }
 
 func (s *Server) apiVisualizationHandler(w http.ResponseWriter, r *http.Request) {
-	data, err := s.db.GetVisualizationData()
+	format, ok := descriptionFormat(w, r)
+	if !ok {
+		return
+	}
+
+	areas, ok := areaFilter(w, r)
+	if !ok {
+		return
+	}
+
+	platforms, ok := platformFilter(w, r)
+	if !ok {
+		return
+	}
+
+	upcoming, ok := upcomingFilter(w, r)
+	if !ok {
+		return
+	}
+
+	langs, ok := summaryLanguages(w, r)
+	if !ok {
+		return
+	}
+
+	data, err := s.db.GetVisualizationData(database.VisualizationOptions{
+		DescriptionFormat: format,
+		Areas:             areas,
+		Platforms:         platforms,
+		IncludeUpcoming:   upcoming,
+		Languages:         langs,
+	})
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -408,6 +555,606 @@ func (s *Server) apiVisualizationHandler(w http.ResponseWriter, r *http.Request)
 	json.NewEncoder(w).Encode(data)
 }
 
+// descriptionFormat は format クエリパラメータ（text / html / excerpt）を検証して返す
+func descriptionFormat(w http.ResponseWriter, r *http.Request) (string, bool) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		return database.DescriptionFormatText, true
+	}
+	if !database.IsValidDescriptionFormat(format) {
+		http.Error(w, "Invalid format: use text, html or excerpt", http.StatusBadRequest)
+		return "", false
+	}
+	return format, true
+}
+
+// areaFilter は area クエリパラメータ（カンマ区切り）を検証して返す
+func areaFilter(w http.ResponseWriter, r *http.Request) ([]string, bool) {
+	param := r.URL.Query().Get("area")
+	if param == "" {
+		return nil, true
+	}
+
+	var areas []string
+	for _, area := range strings.Split(param, ",") {
+		area = strings.TrimSpace(area)
+		if area == "" {
+			continue
+		}
+		if !slices.Contains(scraper.Areas, area) {
+			http.Error(w, fmt.Sprintf("Invalid area: %s", area), http.StatusBadRequest)
+			return nil, false
+		}
+		areas = append(areas, area)
+	}
+	return areas, true
+}
+
+// platformFilter は platform クエリパラメータ（カンマ区切りの linux/amd64 など）を検証して返す
+func platformFilter(w http.ResponseWriter, r *http.Request) ([]platform.Platform, bool) {
+	param := r.URL.Query().Get("platform")
+	if param == "" {
+		return nil, true
+	}
+
+	platforms, err := platform.ParseList(param)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid platform: %v", err), http.StatusBadRequest)
+		return nil, false
+	}
+	return platforms, true
+}
+
+// upcomingFilter は upcoming クエリパラメータ（true の場合は正式リリース前のプレリリースの変更も含める）を検証して返す
+func upcomingFilter(w http.ResponseWriter, r *http.Request) (bool, bool) {
+	param := r.URL.Query().Get("upcoming")
+	if param == "" {
+		return false, true
+	}
+	upcoming, err := strconv.ParseBool(param)
+	if err != nil {
+		http.Error(w, "Invalid upcoming: use true or false", http.StatusBadRequest)
+		return false, false
+	}
+	return upcoming, true
+}
+
+// summaryLanguages は要約の言語の希望順を返す
+// lang クエリパラメータ（カンマ区切りの BCP-47 言語タグ）を優先し、なければ Accept-Language ヘッダーを使う（どちらもない場合は既定の言語）
+func summaryLanguages(w http.ResponseWriter, r *http.Request) ([]string, bool) {
+	w.Header().Add("Vary", "Accept-Language")
+
+	param := r.URL.Query().Get("lang")
+	if param == "" {
+		return langtag.ParseAcceptLanguage(r.Header.Get("Accept-Language")), true
+	}
+
+	var langs []string
+	for _, tag := range strings.Split(param, ",") {
+		if strings.TrimSpace(tag) == "" {
+			continue
+		}
+		lang, err := langtag.Normalize(tag)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Invalid lang: %v", err), http.StatusBadRequest)
+			return nil, false
+		}
+		langs = append(langs, lang)
+	}
+	return langs, true
+}
+
+// apiDiffHandler は ?from=1.22&to=1.24 の範囲のリリースで入った変更を返す（area / platform / format / upcoming で絞り込み可能）
+func (s *Server) apiDiffHandler(w http.ResponseWriter, r *http.Request) {
+	from := r.URL.Query().Get("from")
+	to := r.URL.Query().Get("to")
+	if from == "" || to == "" {
+		http.Error(w, "from and to parameters required", http.StatusBadRequest)
+		return
+	}
+
+	format, ok := descriptionFormat(w, r)
+	if !ok {
+		return
+	}
+
+	areas, ok := areaFilter(w, r)
+	if !ok {
+		return
+	}
+
+	platforms, ok := platformFilter(w, r)
+	if !ok {
+		return
+	}
+
+	upcoming, ok := upcomingFilter(w, r)
+	if !ok {
+		return
+	}
+
+	langs, ok := summaryLanguages(w, r)
+	if !ok {
+		return
+	}
+
+	diff, err := s.db.GetReleaseDiff(from, to, database.VisualizationOptions{
+		DescriptionFormat: format,
+		Areas:             areas,
+		Platforms:         platforms,
+		IncludeUpcoming:   upcoming,
+		Languages:         langs,
+	})
+	if errors.Is(err, database.ErrInvalidVersion) || errors.Is(err, database.ErrInvalidRange) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(diff)
+}
+
+func (s *Server) apiAreasHandler(w http.ResponseWriter, r *http.Request) {
+	counts, err := s.db.GetAreaCounts()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var areas []map[string]interface{}
+	for _, area := range scraper.Areas {
+		areas = append(areas, map[string]interface{}{
+			"area":         area,
+			"change_count": counts[area],
+		})
+	}
+
+	json.NewEncoder(w).Encode(areas)
+}
+
+// apiMinorRevisionSchemaHandler は -import-json で取り込むマイナーリビジョン JSON の JSON Schema を返す
+func (s *Server) apiMinorRevisionSchemaHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/schema+json")
+	w.Write(importer.MinorRevisionSchema)
+}
+
+func (s *Server) apiGodebugHandler(w http.ResponseWriter, r *http.Request) {
+	settings, err := s.db.GetGodebugSettings()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	json.NewEncoder(w).Encode(settings)
+}
+
+// apiGodebugFlipsHandler は ?go=1.21&toolchain=1.24 の範囲で切り替わる GODEBUG 設定を返す
+func (s *Server) apiGodebugFlipsHandler(w http.ResponseWriter, r *http.Request) {
+	goVersion := r.URL.Query().Get("go")
+	toolchain := r.URL.Query().Get("toolchain")
+	if goVersion == "" || toolchain == "" {
+		http.Error(w, "go and toolchain parameters required", http.StatusBadRequest)
+		return
+	}
+
+	flips, err := s.db.GetGodebugFlips(goVersion, toolchain)
+	if errors.Is(err, database.ErrInvalidVersion) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"go":        goVersion,
+		"toolchain": toolchain,
+		"flips":     flips,
+	})
+}
+
+func (s *Server) apiExperimentsHandler(w http.ResponseWriter, r *http.Request) {
+	experiments, err := s.db.GetExperiments()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	json.NewEncoder(w).Encode(experiments)
+}
+
+func (s *Server) apiExperimentHandler(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Path[len("/api/experiments/"):]
+	if name == "" {
+		http.Error(w, "Experiment name required", http.StatusBadRequest)
+		return
+	}
+
+	experiments, err := s.db.GetExperiments()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	for _, exp := range experiments {
+		if exp.Name == name {
+			json.NewEncoder(w).Encode(exp)
+			return
+		}
+	}
+
+	http.Error(w, "Experiment not found", http.StatusNotFound)
+}
+
+func (s *Server) apiIngestionsHandler(w http.ResponseWriter, r *http.Request) {
+	runs, err := s.db.GetIngestionRuns()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	json.NewEncoder(w).Encode(runs)
+}
+
+// apiIngestionHandler は実行記録ごとの操作を扱う
+//   - GET /api/ingestions/{id}: 実行記録
+//   - GET /api/ingestions/{id}/diagnostics: 解析時の診断（?kind=unparsed_heading や ?version=1.22 で絞り込み）
+//   - POST /api/ingestions/{id}/rollback: 実行記録で保存したリリース・変更の削除
+func (s *Server) apiIngestionHandler(w http.ResponseWriter, r *http.Request) {
+	idPart, action, _ := strings.Cut(r.URL.Path[len("/api/ingestions/"):], "/")
+	runID, err := strconv.Atoi(idPart)
+	if err != nil {
+		http.Error(w, "Invalid ingestion run id", http.StatusBadRequest)
+		return
+	}
+
+	var result interface{}
+	switch action {
+	case "":
+		result, err = s.db.GetIngestionRun(runID)
+	case "diagnostics":
+		result, err = s.db.GetIngestionDiagnostics(runID, r.URL.Query().Get("kind"), r.URL.Query().Get("version"))
+	case "rollback":
+		if r.Method != "POST" {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		// リリース・変更を削除するため管理 API と同じ認証を求める
+		actor, ok := s.authorizeAdmin(w, r)
+		if !ok {
+			return
+		}
+		if actor == "" {
+			http.Error(w, "X-Actor header is required", http.StatusBadRequest)
+			return
+		}
+		result, err = s.db.RollbackIngestionRun(runID, actor)
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		http.Error(w, "Ingestion run not found", http.StatusNotFound)
+		return
+	}
+	var stateErr *database.RunStateError
+	if errors.As(err, &stateErr) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	json.NewEncoder(w).Encode(result)
+}
+
+// authorizeAdmin は管理 API の認証を行い、操作者（X-Actor ヘッダー）を返す
+// 認証に失敗した場合はエラーを書き込んで ok = false を返す
+func (s *Server) authorizeAdmin(w http.ResponseWriter, r *http.Request) (actor string, ok bool) {
+	if s.adminToken == "" {
+		http.Error(w, "Admin API is disabled (start the server with -admin-token)", http.StatusForbidden)
+		return "", false
+	}
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || subtle.ConstantTimeCompare([]byte(token), []byte(s.adminToken)) != 1 {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return "", false
+	}
+	return strings.TrimSpace(r.Header.Get("X-Actor")), true
+}
+
+// overrideRequest は上書き設定の作成・更新の要求（key の代わりに change_id で変更を指定できる）
+type overrideRequest struct {
+	ChangeID   int                `json:"change_id"`
+	Key        database.ChangeKey `json:"key"`
+	ChangeType string             `json:"change_type"`
+	SetPackage string             `json:"set_package"`
+	SummaryJa  string             `json:"summary_ja"`
+	Hidden     bool               `json:"hidden"`
+	Note       string             `json:"note"`
+}
+
+// apiOverridesHandler は上書き設定の一覧と作成・更新を扱う
+//   - GET /api/admin/overrides: 上書き設定の一覧（現在のデータで一致する変更の数を含む）
+//   - POST /api/admin/overrides: 自然キー（または change_id）で上書き設定を作成・更新する（X-Actor ヘッダーが必要）
+func (s *Server) apiOverridesHandler(w http.ResponseWriter, r *http.Request) {
+	actor, ok := s.authorizeAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		overrides, err := s.db.GetOverrides()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(overrides)
+
+	case "POST":
+		if actor == "" {
+			http.Error(w, "X-Actor header is required", http.StatusBadRequest)
+			return
+		}
+		var req overrideRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		key := req.Key
+		if req.ChangeID != 0 {
+			var err error
+			if key, err = s.db.GetChangeKey(req.ChangeID); err != nil {
+				http.Error(w, "Change not found", http.StatusNotFound)
+				return
+			}
+		}
+		override := database.ChangeOverride{
+			Key:        key,
+			ChangeType: req.ChangeType,
+			Package:    req.SetPackage,
+			SummaryJa:  req.SummaryJa,
+			Hidden:     req.Hidden,
+			Note:       req.Note,
+		}
+		if err := database.ValidateOverride(override); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		saved, action, err := s.db.SaveOverride(override, actor, "api")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// 一覧と同じく現在のデータで一致する変更の数を返す
+		overrides, err := s.db.GetOverrides()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		for _, o := range overrides {
+			if o.ID == saved.ID {
+				saved = o
+			}
+		}
+		if action == database.OverrideCreated {
+			w.WriteHeader(http.StatusCreated)
+		}
+		json.NewEncoder(w).Encode(saved)
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// apiOverrideHandler は上書き設定ごとの操作と変更履歴を扱う
+//   - GET /api/admin/overrides/audit: すべての変更履歴（?override_id=1 で絞り込み）
+//   - DELETE /api/admin/overrides/{id}: 上書き設定の削除（X-Actor ヘッダーが必要）
+func (s *Server) apiOverrideHandler(w http.ResponseWriter, r *http.Request) {
+	actor, ok := s.authorizeAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	idPart := r.URL.Path[len("/api/admin/overrides/"):]
+	if idPart == "audit" {
+		if r.Method != "GET" {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		overrideID := 0
+		if v := r.URL.Query().Get("override_id"); v != "" {
+			var err error
+			if overrideID, err = strconv.Atoi(v); err != nil {
+				http.Error(w, "Invalid override_id", http.StatusBadRequest)
+				return
+			}
+		}
+		entries, err := s.db.GetOverrideAudit(overrideID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(entries)
+		return
+	}
+
+	id, err := strconv.Atoi(idPart)
+	if err != nil {
+		http.Error(w, "Invalid override id", http.StatusBadRequest)
+		return
+	}
+	if r.Method != "DELETE" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if actor == "" {
+		http.Error(w, "X-Actor header is required", http.StatusBadRequest)
+		return
+	}
+	err = s.db.DeleteOverride(id, actor, "api")
+	if errors.Is(err, database.ErrOverrideNotFound) {
+		http.Error(w, "Override not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// apiTranslationsHandler は変更ごと・言語ごとの要約を扱う
+//   - GET /api/admin/translations?change_id=1: 変更のすべての言語の要約
+//   - PUT /api/admin/translations: 変更の 1 言語分の要約を作成・更新する（確認済みとして保存する。X-Actor ヘッダーが必要）
+//   - DELETE /api/admin/translations?change_id=1&lang=ko: 変更の 1 言語分の要約を削除する（X-Actor ヘッダーが必要）
+func (s *Server) apiTranslationsHandler(w http.ResponseWriter, r *http.Request) {
+	actor, ok := s.authorizeAdmin(w, r)
+	if !ok {
+		return
+	}
+	if r.Method != "GET" && actor == "" {
+		http.Error(w, "X-Actor header is required", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case "GET", "DELETE":
+		changeID, err := strconv.Atoi(r.URL.Query().Get("change_id"))
+		if err != nil {
+			http.Error(w, "Invalid change_id", http.StatusBadRequest)
+			return
+		}
+		if r.Method == "GET" {
+			translations, err := s.db.GetTranslationsOfChange(changeID)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			json.NewEncoder(w).Encode(translations)
+			return
+		}
+
+		err = s.db.DeleteTranslation(changeID, r.URL.Query().Get("lang"))
+		if errors.Is(err, database.ErrTranslationNotFound) {
+			http.Error(w, "Translation not found", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Printf("変更 #%d の要約 (%s) を削除しました (%s)", changeID, r.URL.Query().Get("lang"), actor)
+		w.WriteHeader(http.StatusNoContent)
+
+	case "PUT":
+		var req database.ChangeTranslation
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		saved, err := s.db.SaveTranslation(req, actor)
+		if errors.Is(err, database.ErrChangeNotFound) {
+			http.Error(w, "Change not found", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Printf("変更 #%d の要約 (%s) を保存しました (%s)", saved.ChangeID, saved.Lang, actor)
+		json.NewEncoder(w).Encode(saved)
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// translationReviewRequest は要約の確認結果
+type translationReviewRequest struct {
+	ChangeID int    `json:"change_id"`
+	Lang     string `json:"lang"`
+	Status   string `json:"status"`            // reviewed / rejected
+	Summary  string `json:"summary,omitempty"` // reviewed の場合の修正後の要約（空の場合は今の要約を承認する）
+}
+
+// apiTranslationReviewHandler は自動で作成した要約の確認を扱う
+//   - GET /api/admin/translations/pending?lang=ja&limit=50: 確認待ちの要約（?status=rejected で却下した要約）
+//   - POST /api/admin/translations/review: 要約の承認・修正・却下（X-Actor ヘッダーが必要）
+func (s *Server) apiTranslationReviewHandler(w http.ResponseWriter, r *http.Request) {
+	actor, ok := s.authorizeAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	switch r.URL.Path[len("/api/admin/translations/"):] {
+	case "pending":
+		if r.Method != "GET" {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		limit := 0
+		if v := r.URL.Query().Get("limit"); v != "" {
+			var err error
+			if limit, err = strconv.Atoi(v); err != nil || limit < 0 {
+				http.Error(w, "Invalid limit", http.StatusBadRequest)
+				return
+			}
+		}
+		pending, err := s.db.GetPendingTranslations(r.URL.Query().Get("lang"), r.URL.Query().Get("status"), limit)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(pending)
+
+	case "review":
+		if r.Method != "POST" {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if actor == "" {
+			http.Error(w, "X-Actor header is required", http.StatusBadRequest)
+			return
+		}
+		var req translationReviewRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		reviewed, err := s.db.ReviewTranslation(req.ChangeID, req.Lang, req.Status, req.Summary, actor)
+		if errors.Is(err, database.ErrChangeNotFound) {
+			http.Error(w, "Change not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, database.ErrTranslationNotFound) {
+			http.Error(w, "Translation not found", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Printf("変更 #%d の要約 (%s) を確認しました: %s (%s)", reviewed.ChangeID, reviewed.Lang, reviewed.Status, actor)
+		json.NewEncoder(w).Encode(reviewed)
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
 func (s *Server) apiRefreshHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -0,0 +1,163 @@
+package summarizer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Term は用語集の英語の用語と訳語（訳語が用語と同じ場合は英語のまま残す）
+type Term struct {
+	Term        string
+	Translation string
+}
+
+// Glossary は要約で一貫して使う訳語の一覧
+type Glossary struct {
+	terms map[string]Term // 小文字の用語から項目
+	re    *regexp.Regexp
+}
+
+// NewGlossary は用語集を作成する（長い用語を優先して一致させる）
+func NewGlossary(terms []Term) *Glossary {
+	g := &Glossary{terms: make(map[string]Term)}
+	var patterns []string
+	for _, t := range terms {
+		if t.Term == "" || t.Translation == "" {
+			continue
+		}
+		key := strings.ToLower(t.Term)
+		if _, ok := g.terms[key]; !ok {
+			patterns = append(patterns, regexp.QuoteMeta(t.Term))
+		}
+		g.terms[key] = t
+	}
+	if len(patterns) == 0 {
+		return g
+	}
+	sort.SliceStable(patterns, func(i, j int) bool { return len(patterns[i]) > len(patterns[j]) })
+	g.re = regexp.MustCompile(`(?i)\b(` + strings.Join(patterns, "|") + `)(?:e?s)?\b`)
+	return g
+}
+
+// Len は用語集の項目数
+func (g *Glossary) Len() int { return len(g.terms) }
+
+// Matching は text に含まれる用語を出現順に返す
+func (g *Glossary) Matching(text string) []Term {
+	var matched []Term
+	seen := make(map[string]bool)
+	for _, m := range g.find(text) {
+		key := strings.ToLower(text[m[2]:m[3]])
+		if !seen[key] {
+			seen[key] = true
+			matched = append(matched, g.terms[key])
+		}
+	}
+	return matched
+}
+
+// Apply は要約に英語のまま残った用語を訳語に置き換える
+func (g *Glossary) Apply(summary string) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range g.find(summary) {
+		t := g.terms[strings.ToLower(summary[m[2]:m[3]])]
+		if t.Translation == t.Term {
+			continue
+		}
+		b.WriteString(summary[last:m[0]])
+		b.WriteString(t.Translation)
+		last = m[1]
+	}
+	b.WriteString(summary[last:])
+	return b.String()
+}
+
+// find は用語の出現位置（全体と複数形の語尾を除いた用語）を返す
+// context.Context・net/http・`go vet` のような識別子・パス・コードの一部は除く
+func (g *Glossary) find(text string) [][]int {
+	if g.re == nil {
+		return nil
+	}
+	var found [][]int
+	for _, m := range g.re.FindAllStringSubmatchIndex(text, -1) {
+		if m[0] > 0 && (strings.ContainsRune("_/`", rune(text[m[0]-1])) || text[m[0]-1] == '.' && m[0] > 1 && isWordByte(text[m[0]-2])) {
+			continue
+		}
+		if m[1] < len(text) && (strings.ContainsRune("_/`(", rune(text[m[1]])) || text[m[1]] == '.' && m[1]+1 < len(text) && isWordByte(text[m[1]+1])) {
+			continue
+		}
+		found = append(found, m)
+	}
+	return found
+}
+
+func isWordByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9'
+}
+
+// fingerprint は用語集の内容のハッシュ（用語集を変えたらキャッシュを使わないため）
+func (g *Glossary) fingerprint() string {
+	keys := make([]string, 0, len(g.terms))
+	for k, t := range g.terms {
+		keys = append(keys, k+"\t"+t.Translation)
+	}
+	sort.Strings(keys)
+	sum := sha256.Sum256([]byte(strings.Join(keys, "\n")))
+	return hex.EncodeToString(sum[:4])
+}
+
+// withGlossary は説明文に含まれる用語を要約の指示に加え、要約に残った英語の用語を訳語に置き換える
+type withGlossary struct {
+	inner    Summarizer
+	glossary *Glossary
+}
+
+// WithGlossary は用語集の訳語を使う Summarizer を返す（用語集が空の場合は s をそのまま返す）
+func WithGlossary(s Summarizer, glossary *Glossary) Summarizer {
+	if glossary == nil || glossary.Len() == 0 {
+		return s
+	}
+	return withGlossary{inner: s, glossary: glossary}
+}
+
+// Name は用語集の内容ごとに別の名前にする
+func (g withGlossary) Name() string { return g.inner.Name() + "+glossary:" + g.glossary.fingerprint() }
+
+func (g withGlossary) Summarize(ctx context.Context, in Input) (string, error) {
+	in.Glossary = g.glossary.Matching(in.Description)
+	summary, err := g.inner.Summarize(ctx, in)
+	if err != nil {
+		return "", err
+	}
+	return g.glossary.Apply(summary), nil
+}
+
+// Memory は人が書いた要約を説明文で引く翻訳メモリ（database.TranslationMemory が実装する）
+type Memory interface {
+	Lookup(description string) (summary string, similarity float64, ok bool)
+}
+
+// withMemory は説明文が同じ・ほぼ同じ変更の人が書いた要約があればそれを使う
+type withMemory struct {
+	inner  Summarizer
+	memory Memory
+}
+
+// WithMemory は翻訳メモリの要約を優先する Summarizer を返す
+func WithMemory(s Summarizer, memory Memory) Summarizer {
+	return withMemory{inner: s, memory: memory}
+}
+
+func (m withMemory) Name() string { return m.inner.Name() }
+
+func (m withMemory) Summarize(ctx context.Context, in Input) (string, error) {
+	if summary, _, ok := m.memory.Lookup(in.Description); ok {
+		return summary, nil
+	}
+	return m.inner.Summarize(ctx, in)
+}
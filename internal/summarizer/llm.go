@@ -0,0 +1,141 @@
+package summarizer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultLLMEndpoint は OpenAI 互換 API の既定のベース URL（ローカルのモデルサーバー）
+const DefaultLLMEndpoint = "http://localhost:11434/v1"
+
+// systemPrompt は要約の指示
+const systemPrompt = `あなたは Go のリリースノートを日本語で要約するアシスタントです。
+与えられた変更を、Go を使う開発者向けに日本語の 1〜2 文（100 文字程度）で要約してください。
+関数・型・パッケージなどの識別子は英語のまま残し、要約文だけを出力してください。`
+
+// LLMConfig は OpenAI 互換エンドポイントの設定
+type LLMConfig struct {
+	Endpoint string        // ベース URL（/chat/completions を付けて呼び出す）
+	Model    string        // モデル名
+	APIKey   string        // 空の場合は Authorization ヘッダーを送らない
+	Timeout  time.Duration // 1 件あたりのタイムアウト（0 の場合は 60 秒）
+}
+
+// LLM は OpenAI 互換の Chat Completions API で要約を作成する
+type LLM struct {
+	config LLMConfig
+	client *http.Client
+}
+
+// NewLLM は OpenAI 互換エンドポイントを使う Summarizer を作成する
+func NewLLM(config LLMConfig) (*LLM, error) {
+	if config.Endpoint == "" {
+		config.Endpoint = DefaultLLMEndpoint
+	}
+	if config.Model == "" {
+		return nil, fmt.Errorf("LLM summarizer requires a model name")
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 60 * time.Second
+	}
+	return &LLM{config: config, client: &http.Client{Timeout: config.Timeout}}, nil
+}
+
+// Name はモデルごとに別の名前にする（モデルを変えたらキャッシュを使わない）
+func (l *LLM) Name() string { return "llm:" + l.config.Model }
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatRequest struct {
+	Model       string        `json:"model"`
+	Messages    []chatMessage `json:"messages"`
+	Temperature float64       `json:"temperature"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+func (l *LLM) Summarize(ctx context.Context, in Input) (string, error) {
+	prompt := fmt.Sprintf("パッケージ: %s\n変更種別: %s\n\n%s", in.Package, in.ChangeType, in.Description)
+	if len(in.Glossary) > 0 {
+		var terms []string
+		for _, t := range in.Glossary {
+			terms = append(terms, fmt.Sprintf("- %s: %s", t.Term, t.Translation))
+		}
+		prompt += "\n\n次の用語はこの訳語を使ってください:\n" + strings.Join(terms, "\n")
+	}
+	body, err := json.Marshal(chatRequest{
+		Model: l.config.Model,
+		Messages: []chatMessage{
+			{Role: "system", Content: systemPrompt},
+			{Role: "user", Content: prompt},
+		},
+		Temperature: 0.2,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	url := strings.TrimSuffix(l.config.Endpoint, "/") + "/chat/completions"
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if l.config.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+l.config.APIKey)
+	}
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to call %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response from %s: %w", url, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s returned %s: %s", url, resp.Status, truncate(string(data), 200))
+	}
+
+	var parsed chatResponse
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return "", fmt.Errorf("failed to parse response from %s: %w", url, err)
+	}
+	if len(parsed.Choices) == 0 {
+		return "", fmt.Errorf("%s returned no choices", url)
+	}
+	summary := cleanSummary(parsed.Choices[0].Message.Content)
+	if summary == "" {
+		return "", fmt.Errorf("%s returned an empty summary", url)
+	}
+	return summary, nil
+}
+
+// cleanSummary は応答の改行・前後の空白と括弧を取り除き、1 行にする
+func cleanSummary(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	s = strings.Trim(s, "\"「」")
+	return strings.TrimSpace(s)
+}
+
+func truncate(s string, n int) string {
+	if len([]rune(s)) <= n {
+		return s
+	}
+	return string([]rune(s)[:n]) + "..."
+}
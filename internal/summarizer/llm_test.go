@@ -0,0 +1,209 @@
+package summarizer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"go-ver-trace/internal/classifier"
+	"go-ver-trace/internal/database"
+)
+
+// fakeEndpoint は /chat/completions に handler で応答するローカルのエンドポイントを起動し、呼び出し回数を数える
+func fakeEndpoint(t *testing.T, handler func(w http.ResponseWriter, req chatRequest)) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/chat/completions" {
+			t.Errorf("request = %s %s, want POST /v1/chat/completions", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		var req chatRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		calls.Add(1)
+		handler(w, req)
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+// replyWith は content を 1 件目の choice として返す
+func replyWith(content string) func(w http.ResponseWriter, req chatRequest) {
+	return func(w http.ResponseWriter, req chatRequest) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{{"message": map[string]string{"role": "assistant", "content": content}}},
+		})
+	}
+}
+
+func newTestLLM(t *testing.T, server *httptest.Server, model string) *LLM {
+	t.Helper()
+	l, err := NewLLM(LLMConfig{Endpoint: server.URL + "/v1/", Model: model, APIKey: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+var testInput = Input{Package: "net/http", ChangeType: classifier.Added, Description: "The new ServeMux patterns support methods and wildcards."}
+
+func TestLLMSummarize(t *testing.T) {
+	var got chatRequest
+	server, _ := fakeEndpoint(t, func(w http.ResponseWriter, req chatRequest) {
+		got = req
+		replyWith("「ServeMux のパターンで\n  メソッドとワイルドカードを指定できるようになった。」")(w, req)
+	})
+
+	summary, err := newTestLLM(t, server, "test-model").Summarize(context.Background(), testInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "ServeMux のパターンで メソッドとワイルドカードを指定できるようになった。"; summary != want {
+		t.Errorf("summary = %q, want %q", summary, want)
+	}
+	if got.Model != "test-model" {
+		t.Errorf("model = %q, want test-model", got.Model)
+	}
+	if len(got.Messages) != 2 || got.Messages[0].Role != "system" || got.Messages[1].Role != "user" {
+		t.Fatalf("messages = %+v, want system and user messages", got.Messages)
+	}
+	for _, s := range []string{testInput.Package, testInput.ChangeType, testInput.Description} {
+		if !strings.Contains(got.Messages[1].Content, s) {
+			t.Errorf("prompt %q does not contain %q", got.Messages[1].Content, s)
+		}
+	}
+}
+
+func TestLLMSummarizeAuthorization(t *testing.T) {
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		replyWith("要約")(w, chatRequest{})
+	}))
+	defer server.Close()
+
+	if _, err := newTestLLM(t, server, "m").Summarize(context.Background(), testInput); err != nil {
+		t.Fatal(err)
+	}
+	if auth != "Bearer secret" {
+		t.Errorf("Authorization = %q, want Bearer secret", auth)
+	}
+}
+
+func TestLLMSummarizeErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler func(w http.ResponseWriter, req chatRequest)
+		want    string // エラーに含まれる文字列
+	}{
+		{
+			name: "non-200 status",
+			handler: func(w http.ResponseWriter, req chatRequest) {
+				http.Error(w, "model not loaded", http.StatusServiceUnavailable)
+			},
+			want: "503 Service Unavailable: model not loaded",
+		},
+		{
+			name: "empty choices",
+			handler: func(w http.ResponseWriter, req chatRequest) {
+				w.Write([]byte(`{"choices": []}`))
+			},
+			want: "returned no choices",
+		},
+		{
+			name:    "empty summary",
+			handler: replyWith(" 「」\n "),
+			want:    "returned an empty summary",
+		},
+		{
+			name: "invalid JSON",
+			handler: func(w http.ResponseWriter, req chatRequest) {
+				w.Write([]byte(`not json`))
+			},
+			want: "failed to parse response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := fakeEndpoint(t, tt.handler)
+			summary, err := newTestLLM(t, server, "m").Summarize(context.Background(), testInput)
+			if err == nil {
+				t.Fatalf("Summarize returned %q, want an error", summary)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %q, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestLLMSummarizeCache は入力のハッシュごとに保存した要約を使い、同じ入力ではエンドポイントを呼ばないことを確認する
+func TestLLMSummarizeCache(t *testing.T) {
+	db, err := database.New(filepath.Join(t.TempDir(), "cache.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	server, calls := fakeEndpoint(t, replyWith("要約"))
+	s := WithCache(newTestLLM(t, server, "model-a"), db)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := s.Summarize(ctx, testInput); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("endpoint called %d times for the same input, want 1", n)
+	}
+
+	// プロンプトに含まれるパッケージ・変更種別・説明文のいずれかが変われば要約を作り直す
+	want := int32(1)
+	for name, change := range map[string]func(*Input){
+		"package":     func(in *Input) { in.Package = "net/http/httptest" },
+		"change type": func(in *Input) { in.ChangeType = classifier.BehaviorChange },
+		"description": func(in *Input) { in.Description = "A different description." },
+	} {
+		other := testInput
+		change(&other)
+		if _, err := s.Summarize(ctx, other); err != nil {
+			t.Fatal(err)
+		}
+		want++
+		if n := calls.Load(); n != want {
+			t.Errorf("endpoint called %d times after changing the %s, want %d", n, name, want)
+		}
+	}
+
+	// モデルが変わればキャッシュを使わない
+	if _, err := WithCache(newTestLLM(t, server, "model-b"), db).Summarize(ctx, testInput); err != nil {
+		t.Fatal(err)
+	}
+	if n := calls.Load(); n != want+1 {
+		t.Errorf("endpoint called %d times after changing the model, want %d", n, want+1)
+	}
+
+	// エラーの場合はキャッシュに保存しない
+	failing, failingCalls := fakeEndpoint(t, func(w http.ResponseWriter, req chatRequest) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	})
+	s = WithCache(newTestLLM(t, failing, "model-c"), db)
+	for i := 0; i < 2; i++ {
+		if _, err := s.Summarize(ctx, testInput); err == nil {
+			t.Fatal("Summarize succeeded, want an error")
+		}
+	}
+	if n := failingCalls.Load(); n != 2 {
+		t.Errorf("failing endpoint called %d times, want 2 (errors must not be cached)", n)
+	}
+}
@@ -0,0 +1,98 @@
+// Package summarizer は変更の説明文から日本語の要約を作成する
+// 規則による定型文のほか、OpenAI 互換の HTTP エンドポイント（ローカルのモデルサーバーなど）を使える
+package summarizer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go-ver-trace/internal/classifier"
+)
+
+// Input は要約する変更
+type Input struct {
+	Package     string
+	ChangeType  string
+	Description string // 説明文全体
+	Excerpt     string // 一覧表示用の抜粋（空の場合は説明文を使う）
+	Glossary    []Term // 説明文に含まれる用語集の用語（WithGlossary が設定する）
+}
+
+// Summarizer は変更の日本語の要約を作成する
+type Summarizer interface {
+	// Name はキャッシュと記録に使う名前（モデルが変われば別の名前になる）
+	Name() string
+	// Summarize は変更の日本語の要約を返す
+	Summarize(ctx context.Context, in Input) (string, error)
+}
+
+// RuleBased は変更種別と説明文のキーワードから定型文の要約を作る（取り込み時の既定）
+type RuleBased struct{}
+
+func (RuleBased) Name() string { return "rule" }
+
+// Summarize は取り込み時と同じく抜粋を対象に要約する
+func (RuleBased) Summarize(_ context.Context, in Input) (string, error) {
+	text := in.Excerpt
+	if text == "" {
+		text = in.Description
+	}
+	return classifier.SummaryJa(text, in.ChangeType), nil
+}
+
+// Cache は要約の保存先（database.Database が実装する）
+// key は要約の入力（パッケージ・変更種別・説明文）をまとめた文字列
+type Cache interface {
+	GetCachedSummary(summarizer, key string) (string, bool, error)
+	SaveCachedSummary(summarizer, key, summary string) error
+}
+
+// cached は要約を入力ごとに保存し、同じ入力の要約を作り直さない
+type cached struct {
+	inner Summarizer
+	cache Cache
+}
+
+// WithCache は s の要約を cache に保存する Summarizer を返す
+func WithCache(s Summarizer, cache Cache) Summarizer {
+	return cached{inner: s, cache: cache}
+}
+
+func (c cached) Name() string { return c.inner.Name() }
+
+func (c cached) Summarize(ctx context.Context, in Input) (string, error) {
+	key := cacheKey(in)
+	if summary, ok, err := c.cache.GetCachedSummary(c.inner.Name(), key); err != nil {
+		return "", err
+	} else if ok {
+		return summary, nil
+	}
+
+	summary, err := c.inner.Summarize(ctx, in)
+	if err != nil {
+		return "", err
+	}
+	if err := c.cache.SaveCachedSummary(c.inner.Name(), key, summary); err != nil {
+		return "", err
+	}
+	return summary, nil
+}
+
+// cacheKey はプロンプトに含まれる項目から要約のキャッシュのキーを作る
+// 再分類や上書き設定でパッケージ・変更種別が変わった場合は要約を作り直す
+func cacheKey(in Input) string {
+	return strings.Join([]string{in.Package, in.ChangeType, in.Description}, "\n")
+}
+
+// New は名前から Summarizer を作成する（llm の場合は OpenAI 互換エンドポイントの設定を使う）
+func New(name string, llm LLMConfig) (Summarizer, error) {
+	switch name {
+	case "", "rule":
+		return RuleBased{}, nil
+	case "llm":
+		return NewLLM(llm)
+	default:
+		return nil, fmt.Errorf("unknown summarizer %q (rule / llm)", name)
+	}
+}
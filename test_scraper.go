@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 
@@ -11,7 +12,7 @@ func main() {
 	rs := scraper.NewReleaseScraper()
 	
 	// Go 1.19の情報を取得
-	release, err := rs.GetReleaseInfo([]string{"1.19"})
+	release, err := rs.GetReleaseInfo(context.Background(), []string{"1.19"})
 	if err != nil {
 		log.Fatal(err)
 	}